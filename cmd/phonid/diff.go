@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+// runDiff reports whether words issued under one rc file still decode under another.
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phonid diff OLD NEW")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Checks that every word issued under OLD decodes to the same number under NEW.")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("diff needs exactly two rc files")
+	}
+
+	oldPath, newPath := fs.Arg(0), fs.Arg(1)
+	oldConfig, _, err := phonid.LoadConfigRC(oldPath)
+	if err != nil {
+		return err
+	}
+	newConfig, _, err := phonid.LoadConfigRC(newPath)
+	if err != nil {
+		return err
+	}
+
+	for _, side := range []struct {
+		label, path string
+		config      *phonid.Config
+	}{{"old", oldPath, oldConfig}, {"new", newPath, newConfig}} {
+		fingerprint, err := side.config.Fingerprint()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s: %s (fingerprint %s)\n", side.label, side.path, fingerprint)
+	}
+
+	report, err := phonid.CheckCompatibility(oldConfig, newConfig)
+	if err != nil {
+		return err
+	}
+
+	if !report.Compatible {
+		fmt.Fprintf(stdout, "incompatible: %s\n", report.CounterExample)
+		return errors.New("configurations are incompatible")
+	}
+
+	method := "proven from the pattern structure"
+	if report.Exhaustive {
+		method = "checked word by word"
+	}
+	fmt.Fprintf(stdout, "compatible: every old word decodes to the same number (%s)\n", method)
+	return nil
+}
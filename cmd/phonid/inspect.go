@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+// runInspect prints per-pattern capacity and entropy for an rc file.
+func runInspect(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	configPath := fs.String("config", "", "path to the phonidrc file (default: search upwards like git)")
+	prefix := fs.String("prefix", "", "look for .<prefix>.phonidrc first when searching")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := resolveConfigPath(*configPath, *prefix)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := phonid.LoadPhonidRCLenient(path)
+	if err != nil {
+		return err
+	}
+
+	encoder, err := phonid.NewPhoneticEncoder(config)
+	if err != nil {
+		return err
+	}
+
+	stats := encoder.Stats()
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "PATTERN\tLENGTH\tCOMBINATIONS\tBITS\tRANGE\tCHARS/BIT\t")
+	for _, p := range stats.Patterns {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%d-%d\t%.3f\t\n",
+			p.Pattern, p.Length, p.Combinations, p.EntropyBits, p.MinValue, p.MaxValue, p.CharsPerBit)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout)
+	fmt.Fprintf(stdout, "Total words: %d\n", stats.TotalCombinations)
+	fmt.Fprintf(stdout, "Max value:   %d\n", stats.MaxValue)
+	fmt.Fprintf(stdout, "Entropy:     %.2f bits\n", stats.EntropyBits)
+	fmt.Fprintf(stdout, "Bit width:   %d\n", stats.BitWidth)
+
+	return nil
+}
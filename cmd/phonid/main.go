@@ -0,0 +1,74 @@
+// Command phonid inspects and exercises phonid configurations.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+type (
+	// command is a single phonid subcommand.
+	command struct {
+		summary string
+		run     func(args []string, stdout io.Writer) error
+	}
+)
+
+var commands = map[string]command{
+	"diff":    {summary: "check that words issued under one configuration decode under another", run: runDiff},
+	"inspect": {summary: "report capacity and entropy of a configuration", run: runInspect},
+	"migrate": {summary: "re-encode words from one configuration to another", run: runMigrate},
+	"schema":  {summary: "print a JSON Schema or OpenAPI format for the words of a configuration", run: runSchema},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "phonid:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches args to the matching subcommand.
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		usage(stdout)
+		return errors.New("missing command")
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stdout)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return cmd.run(args[1:], stdout)
+}
+
+// usage prints the list of available subcommands.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: phonid <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+// resolveConfigPath returns the explicit path, or discovers one from the working directory.
+func resolveConfigPath(explicit, prefix string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	return phonid.FindPhonidRC(".", prefix)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+// runMigrate re-encodes words read from a file or stdin from one rc file to another.
+func runMigrate(args []string, stdout io.Writer) (err error) {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	from := fs.String("from", "", "rc file the words were issued under (required)")
+	to := fs.String("to", "", "rc file to re-encode the words with (required)")
+	mappingPath := fs.String("mapping", "", "also write an old,new CSV mapping to this file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phonid migrate -from OLD -to NEW [-mapping FILE] [WORDS]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Reads one word per line from WORDS, or stdin, and prints the re-encoded words.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" || *to == "" || fs.NArg() > 1 {
+		fs.Usage()
+		return errors.New("migrate needs -from, -to and at most one input file")
+	}
+
+	migration, err := loadMigration(*from, *to)
+	if err != nil {
+		return err
+	}
+
+	var mapping io.Writer
+	if *mappingPath != "" {
+		// #nosec G304 -- the user chooses where to write the mapping
+		file, err := os.Create(*mappingPath)
+		if err != nil {
+			return fmt.Errorf("failed to create mapping: %w", err)
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("failed to write mapping: %w", closeErr)
+			}
+		}()
+		mapping = file
+	}
+
+	input := io.Reader(os.Stdin)
+	if fs.NArg() == 1 {
+		// #nosec G304 -- the user names the file to migrate
+		file, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", fs.Arg(0), err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	_, err = migration.MigrateStream(input, stdout, mapping)
+	return err
+}
+
+// loadMigration builds a migration between the codecs of two rc files.
+func loadMigration(fromPath, toPath string) (*phonid.Migration, error) {
+	codecs := make([]phonid.WordCodec, 0, 2)
+	for _, path := range []string{fromPath, toPath} {
+		config, _, err := phonid.LoadConfigRC(path)
+		if err != nil {
+			return nil, err
+		}
+		codec, err := phonid.NewCodec(config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		codecs = append(codecs, codec)
+	}
+	return phonid.NewMigration(codecs[0], codecs[1])
+}
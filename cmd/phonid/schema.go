@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+// runSchema prints the JSON Schema, or an OpenAPI component, for the words of an rc file.
+func runSchema(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	configPath := fs.String("config", "", "path to the phonidrc file (default: search upwards like git)")
+	prefix := fs.String("prefix", "", "look for .<prefix>.phonidrc first when searching")
+	format := fs.String("format", phonid.DefaultSchemaFormat, "format name to register")
+	openAPI := fs.String("openapi", "", "print an OpenAPI components fragment with this schema name instead")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := resolveConfigPath(*configPath, *prefix)
+	if err != nil {
+		return err
+	}
+
+	config, _, err := phonid.LoadPhonidRCLenient(path)
+	if err != nil {
+		return err
+	}
+
+	schema, err := phonid.NewJSONSchema(config, *format)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if *openAPI != "" {
+		data, err = schema.MarshalOpenAPI(*openAPI)
+	} else {
+		data, err = json.MarshalIndent(schema, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, string(data))
+	return err
+}
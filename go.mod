@@ -6,4 +6,5 @@ require (
 	github.com/creasty/defaults v1.8.0
 	github.com/pelletier/go-toml/v2 v2.2.4
 	golang.org/x/text v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
 )
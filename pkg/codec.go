@@ -0,0 +1,117 @@
+package phonid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNilCodec = errors.New("codec is nil")
+
+// Codec turns numbers into shuffled phonetic words and back: the Feistel
+// shuffler scrambles the order, the phonetic encoder spells the result.
+type Codec struct {
+	encoder  *PhoneticEncoder
+	shuffler *FeistelShuffler
+	maxValue uint64
+}
+
+// NewCodec validates config and creates the codec it describes.
+func NewCodec(config *Config) (*Codec, error) {
+	shuffler, err := config.NewShuffler()
+	if err != nil {
+		return nil, err
+	}
+
+	encoder, err := NewPhoneticEncoder(config.Phonetic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	largestPattern := encoder.patternEncoders[len(encoder.patternEncoders)-1]
+	return &Codec{
+		encoder:  encoder,
+		shuffler: shuffler,
+		// #nosec G115 -- totalCombinations is positive
+		maxValue: uint64(largestPattern.totalCombinations) - 1,
+	}, nil
+}
+
+// Encode shuffles number and spells it as a word. A nil codec returns an error.
+func (c *Codec) Encode(number PositiveInt) (string, error) {
+	if c == nil {
+		return "", errNilCodec
+	}
+	if err := number.Validate(); err != nil {
+		return "", err
+	}
+	// #nosec G115 -- number is validated non-negative
+	if uint64(number) > c.maxValue {
+		return "", fmt.Errorf("number %d exceeds codec capacity (max: %d)", number, c.maxValue)
+	}
+
+	// #nosec G115 -- number is validated non-negative
+	shuffled, err := cycleWalk(c.shuffler.Encode, uint64(number), c.maxValue)
+	if err != nil {
+		return "", err
+	}
+	if c.shuffler.lossy() {
+		// Encoding version 1 maps two numbers to each word of an odd-width space
+		// #nosec G115 -- number is validated non-negative
+		if decoded, err := cycleWalk(c.shuffler.Decode, shuffled, c.maxValue); err != nil || decoded != uint64(number) {
+			return "", fmt.Errorf("number %d cannot be encoded reversibly with an odd bit width under encoding version 1",
+				number)
+		}
+	}
+	// #nosec G115 -- shuffled is at most maxValue, which fits in PositiveInt
+	return c.encoder.Encode(PositiveInt(shuffled))
+}
+
+// Decode parses word and reverses the shuffle. A nil codec returns an error.
+func (c *Codec) Decode(word string) (int, error) {
+	if c == nil {
+		return 0, errNilCodec
+	}
+	shuffled, err := c.encoder.Decode(word)
+	if err != nil {
+		return 0, err
+	}
+	// #nosec G115 -- Decode returns non-negative numbers
+	if uint64(shuffled) > c.maxValue {
+		return 0, fmt.Errorf("word %q is outside the codec capacity", word)
+	}
+
+	// #nosec G115 -- Decode returns non-negative numbers
+	number, err := cycleWalk(c.shuffler.Decode, uint64(shuffled), c.maxValue)
+	if err != nil {
+		return 0, err
+	}
+	// #nosec G115 -- number is at most maxValue, which fits in int
+	return int(number), nil
+}
+
+// ValidatePreflight checks preflight vectors against the shuffled encoding.
+// With zero rounds this is identical to PhoneticEncoder.ValidatePreflight.
+func (c *Codec) ValidatePreflight(checks []PreflightCheck) error {
+	return validatePreflight(c, checks)
+}
+
+// MaxValue returns the largest number the codec accepts.
+func (c *Codec) MaxValue() uint64 {
+	return c.maxValue
+}
+
+// cycleWalk applies step until the value lands in [0, maxValue].
+// The shuffler permutes a power-of-two range, so the orbit of any in-range
+// value returns to the range; this keeps the mapping bijective.
+func cycleWalk(step func(uint64) (uint64, error), value, maxValue uint64) (uint64, error) {
+	for {
+		next, err := step(value)
+		if err != nil {
+			return 0, err
+		}
+		if next <= maxValue {
+			return next, nil
+		}
+		value = next
+	}
+}
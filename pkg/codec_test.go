@@ -0,0 +1,75 @@
+package phonid_test
+
+import (
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestCodec_CompleteBijection(t *testing.T) {
+	config, err := NewConfigWithOptions(
+		WithPhonetic(&PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdkst"),
+				Vowel:     RuneSet("aeiou"),
+			},
+		}),
+		WithRounds(4),
+		WithSeed(7),
+	)
+	if err != nil {
+		t.Fatalf("NewConfigWithOptions() error = %v", err)
+	}
+
+	codec, err := NewCodec(config)
+	if err != nil {
+		t.Fatalf("NewCodec() error = %v", err)
+	}
+
+	// 3125 values shuffled over 12 bits with cycle walking
+	seen := make(map[string]bool)
+	for n := range PositiveInt(codec.MaxValue() + 1) {
+		word, err := codec.Encode(n)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", n, err)
+		}
+		if seen[word] {
+			t.Fatalf("Encode(%d) = %q is a duplicate", n, word)
+		}
+		seen[word] = true
+
+		decoded, err := codec.Decode(word)
+		if err != nil || decoded != int(n) {
+			t.Fatalf("Decode(%q) = %d, %v, want %d", word, decoded, err, n)
+		}
+	}
+
+	if _, err := codec.Encode(PositiveInt(codec.MaxValue() + 1)); err == nil {
+		t.Error("Encode() should reject numbers beyond the capacity")
+	}
+}
+
+func TestCodec_ZeroRoundsMatchesEncoder(t *testing.T) {
+	config, err := NewConfigWithOptions()
+	if err != nil {
+		t.Fatalf("NewConfigWithOptions() error = %v", err)
+	}
+
+	codec, err := NewCodec(config)
+	if err != nil {
+		t.Fatalf("NewCodec() error = %v", err)
+	}
+	encoder, err := NewPhoneticEncoder(config.Phonetic)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	for _, n := range []PositiveInt{0, 42, 1337} {
+		got, _ := codec.Encode(n)
+		want, _ := encoder.Encode(n)
+		if got != want {
+			t.Errorf("Encode(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package phonid
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// compatibilityExhaustiveLimit is the largest old capacity for which
+	// CheckCompatibility decodes every word instead of reasoning structurally.
+	compatibilityExhaustiveLimit = 1 << 20
+	// compatibilitySamples bounds the sequential and strided probes used to
+	// find a counter-example when compatibility cannot be proven.
+	compatibilitySamples = 1 << 12
+)
+
+type (
+	// CompatibilityReport is the result of CheckCompatibility.
+	CompatibilityReport struct {
+		Compatible bool
+		// Exhaustive is set when the old config was small enough to check
+		// word by word; otherwise the pattern structure and probes decided.
+		Exhaustive bool
+		// CounterExample is the smallest failing number found, if any.
+		CounterExample *CounterExample
+	}
+
+	// CounterExample is a word issued by the old config that the new config
+	// decodes differently.
+	CounterExample struct {
+		Number    PositiveInt // Number the old config encoded
+		Word      string      // Word the old config issued for Number
+		Decoded   int         // Number the new config decoded, if DecodeErr is nil
+		DecodeErr error       // Why the new config rejected Word
+	}
+)
+
+func (ce *CounterExample) String() string {
+	if ce.DecodeErr != nil {
+		return fmt.Sprintf("%d encodes as %q, which the new config rejects: %v", ce.Number, ce.Word, ce.DecodeErr)
+	}
+	return fmt.Sprintf("%d encodes as %q, which the new config decodes to %d", ce.Number, ce.Word, ce.Decoded)
+}
+
+// CheckCompatibility reports whether every word produced by oldConfig
+// decodes to the same number under newConfig, so a config change keeps
+// published IDs valid. Small configs are checked exhaustively. Larger ones
+// are compatible when the shuffle is unchanged and every old pattern has a
+// counterpart whose positions only differ by symbols appended to the first,
+// most significant, position. Otherwise a counter-example is searched for;
+// an error is returned if neither a proof nor a counter-example is found.
+func CheckCompatibility(oldConfig, newConfig *Config) (*CompatibilityReport, error) {
+	oldCodec, err := NewCodec(oldConfig)
+	if err != nil {
+		return nil, fmt.Errorf("old config: %w", err)
+	}
+	newCodec, err := NewCodec(newConfig)
+	if err != nil {
+		return nil, fmt.Errorf("new config: %w", err)
+	}
+
+	if oldCodec.maxValue < compatibilityExhaustiveLimit {
+		report := &CompatibilityReport{Compatible: true, Exhaustive: true}
+		for number := range oldCodec.maxValue + 1 {
+			// #nosec G115 -- number is below compatibilityExhaustiveLimit
+			counterExample, err := checkNumber(oldCodec, newCodec, PositiveInt(number))
+			if err != nil {
+				return nil, err
+			}
+			if counterExample != nil {
+				report.Compatible = false
+				report.CounterExample = counterExample
+				break
+			}
+		}
+		return report, nil
+	}
+
+	if sameShuffle(oldCodec, newCodec) && structurallyCompatible(oldCodec.encoder, newCodec.encoder) {
+		return &CompatibilityReport{Compatible: true}, nil
+	}
+
+	// Probes are sorted, so the first failure is the smallest one found
+	for _, number := range compatibilityProbes(oldCodec) {
+		counterExample, err := checkNumber(oldCodec, newCodec, number)
+		if err != nil {
+			return nil, err
+		}
+		if counterExample != nil {
+			return &CompatibilityReport{CounterExample: counterExample}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not prove compatibility of %d words, and no counter-example was found",
+		oldCodec.maxValue+1)
+}
+
+// checkNumber encodes number with the old codec and returns a counter-example
+// if the new codec does not decode it back.
+func checkNumber(oldCodec, newCodec *Codec, number PositiveInt) (*CounterExample, error) {
+	word, err := oldCodec.Encode(number)
+	if err != nil {
+		return nil, fmt.Errorf("old config cannot encode %d: %w", number, err)
+	}
+
+	decoded, err := newCodec.Decode(word)
+	if err != nil {
+		return &CounterExample{Number: number, Word: word, DecodeErr: err}, nil
+	}
+	if decoded != int(number) {
+		return &CounterExample{Number: number, Word: word, Decoded: decoded}, nil
+	}
+	return nil, nil
+}
+
+// sameShuffle reports whether both codecs shuffle every number identically.
+// Cycle walking depends on the capacity, so it must match unless neither
+// codec shuffles at all. Encoding versions split odd bit widths differently.
+func sameShuffle(oldCodec, newCodec *Codec) bool {
+	a, b := oldCodec.shuffler, newCodec.shuffler
+	if a.rounds == 0 && b.rounds == 0 {
+		return true
+	}
+	return oldCodec.maxValue == newCodec.maxValue &&
+		a.bitWidth == b.bitWidth &&
+		(a.bitWidth%2 == 0 || a.evenSplit == b.evenSplit) &&
+		slices.Equal(a.roundKeys, b.roundKeys) &&
+		slices.Equal(a.secret, b.secret)
+}
+
+// structurallyCompatible reports whether every old pattern has a new
+// counterpart that decodes each of its words to the same number.
+func structurallyCompatible(oldEncoder, newEncoder *PhoneticEncoder) bool {
+	for _, oldPattern := range oldEncoder.patternEncoders {
+		if !slices.ContainsFunc(newEncoder.patternEncoders, func(newPattern *PatternEncoder) bool {
+			return extendsPattern(oldPattern, newPattern)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// extendsPattern reports whether newPattern parses every oldPattern word to
+// the same number: positions must match, except that the first position may
+// append symbols, since its weight does not depend on its own base.
+func extendsPattern(oldPattern, newPattern *PatternEncoder) bool {
+	if len(oldPattern.positions) != len(newPattern.positions) {
+		return false
+	}
+
+	// Ranking under phonotactic rules depends on the whole transition table
+	if oldPattern.suffixCounts != nil || newPattern.suffixCounts != nil {
+		return slices.EqualFunc(oldPattern.positions, newPattern.positions, func(a, b Position) bool {
+			return slices.Equal(a.symbols, b.symbols)
+		}) && slices.EqualFunc(oldPattern.transitions, newPattern.transitions, func(a, b [][]bool) bool {
+			return slices.EqualFunc(a, b, slices.Equal)
+		})
+	}
+
+	for i, oldPosition := range oldPattern.positions {
+		newSymbols := newPattern.positions[i].symbols
+		if i > 0 && len(newSymbols) != len(oldPosition.symbols) {
+			return false
+		}
+		if len(newSymbols) < len(oldPosition.symbols) || !slices.Equal(newSymbols[:len(oldPosition.symbols)], oldPosition.symbols) {
+			return false
+		}
+	}
+	return true
+}
+
+// compatibilityProbes returns numbers likely to expose an incompatibility:
+// the first numbers, a strided sample of the whole range, and for every
+// pattern its boundaries and each symbol of each position.
+func compatibilityProbes(codec *Codec) []PositiveInt {
+	limit := codec.maxValue + 1
+	var shuffled []uint64
+
+	probes := make([]PositiveInt, 0, 2*compatibilitySamples)
+	stride := max(limit/compatibilitySamples, 1)
+	for i := range uint64(compatibilitySamples) {
+		// #nosec G115 -- probes stay below the codec capacity
+		probes = append(probes, PositiveInt(i), PositiveInt(min(i*stride, limit-1)))
+	}
+
+	var lower uint64
+	for _, pattern := range codec.encoder.patternEncoders {
+		// #nosec G115 -- totalCombinations is positive
+		upper := uint64(pattern.totalCombinations)
+		shuffled = append(shuffled, lower, upper-1)
+
+		weight := uint64(1)
+		for i := len(pattern.positions) - 1; i >= 0 && weight < upper-lower; i-- {
+			// #nosec G115 -- bases are positive
+			base := uint64(pattern.positions[i].base)
+			for digit := range base {
+				offset := digit * weight
+				if offset < upper-lower {
+					shuffled = append(shuffled, lower+offset, upper-1-offset)
+				}
+			}
+			weight *= base
+		}
+		lower = upper
+	}
+
+	// Pattern probes address words; map them back to the numbers they encode
+	for _, value := range shuffled {
+		// #nosec G115 -- value is below the codec capacity
+		word, err := codec.encoder.Encode(PositiveInt(value))
+		if err != nil {
+			continue
+		}
+		number, err := codec.Decode(word)
+		if err != nil {
+			continue
+		}
+		probes = append(probes, PositiveInt(number))
+	}
+
+	slices.Sort(probes)
+	return slices.Compact(probes)
+}
@@ -0,0 +1,161 @@
+package phonid_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func compatConfig(t *testing.T, patterns []string, placeholders PlaceholderMap, opts ...ConfigOption) *Config {
+	t.Helper()
+	phonetic := &PhonidConfig{Patterns: patterns, Placeholders: placeholders}
+	config, err := NewConfigWithOptions(append([]ConfigOption{WithPhonetic(phonetic)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	small := PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}
+	reordered := PlaceholderMap{Consonant: RuneSet("dbkst"), Vowel: RuneSet("aeiou")}
+	large := PlaceholderMap{Liquid: RuneSet("lmr"), Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}
+	appended := PlaceholderMap{Liquid: RuneSet("lmrw"), Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}
+	long := []string{"LVCVCVCVCVC"}
+	// 16^4 * 8^3 = 2^25 words: an odd bit width above the exhaustive limit
+	odd := PlaceholderMap{Consonant: RuneSet("bcdfghjklmnpqrst"), Vowel: RuneSet("aeiouyáé")}
+
+	tests := []struct {
+		name           string
+		old, new       *Config
+		wantCompatible bool
+		wantExhaustive bool
+		wantNumber     PositiveInt
+	}{
+		{
+			name:           "identical",
+			old:            compatConfig(t, []string{"CVC", "CVCVC"}, small, WithRounds(4), WithSeed(1)),
+			new:            compatConfig(t, []string{"CVC", "CVCVC"}, small, WithRounds(4), WithSeed(1)),
+			wantCompatible: true,
+			wantExhaustive: true,
+		},
+		{
+			name:           "longer pattern added",
+			old:            compatConfig(t, []string{"CVC", "CVCVC"}, small),
+			new:            compatConfig(t, []string{"CVC", "CVCVC", "CVCVCVC"}, small),
+			wantCompatible: true,
+			wantExhaustive: true,
+		},
+		{
+			name:           "reordered alphabet",
+			old:            compatConfig(t, []string{"CVC", "CVCVC"}, small),
+			new:            compatConfig(t, []string{"CVC", "CVCVC"}, reordered),
+			wantExhaustive: true,
+		},
+		{
+			name:           "longer pattern added while shuffled",
+			old:            compatConfig(t, []string{"CVC", "CVCVC"}, small, WithRounds(4), WithSeed(1)),
+			new:            compatConfig(t, []string{"CVC", "CVCVC", "CVCVCVC"}, small, WithRounds(4), WithSeed(1)),
+			wantExhaustive: true,
+		},
+		{
+			name:           "large, first position appended",
+			old:            compatConfig(t, long, large),
+			new:            compatConfig(t, long, appended),
+			wantCompatible: true,
+		},
+		{
+			name:           "large, shorter pattern added",
+			old:            compatConfig(t, long, large, WithRounds(4), WithSeed(1)),
+			new:            compatConfig(t, append([]string{"CVC"}, long...), large, WithRounds(4), WithSeed(1)),
+			wantCompatible: true,
+		},
+		{
+			name:       "large, last position appended",
+			old:        compatConfig(t, []string{"CVCVCVCVCVL"}, large),
+			new:        compatConfig(t, []string{"CVCVCVCVCVL"}, appended),
+			wantNumber: 3,
+		},
+		{
+			name:       "large, seed changed",
+			old:        compatConfig(t, long, large, WithRounds(4), WithSeed(1)),
+			new:        compatConfig(t, long, large, WithRounds(4), WithSeed(2)),
+			wantNumber: 0,
+		},
+		{
+			name:       "large, encoding version changed",
+			old:        compatConfig(t, []string{"CVCVCVC"}, odd, WithRounds(4), WithSeed(42), WithEncodingVersion(1)),
+			new:        compatConfig(t, []string{"CVCVCVC"}, odd, WithRounds(4), WithSeed(42), WithEncodingVersion(2)),
+			wantNumber: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := CheckCompatibility(tt.old, tt.new)
+			if err != nil {
+				t.Fatalf("CheckCompatibility() error = %v", err)
+			}
+			if report.Compatible != tt.wantCompatible {
+				t.Fatalf("Compatible = %v, want %v (counter-example: %v)",
+					report.Compatible, tt.wantCompatible, report.CounterExample)
+			}
+			if report.Exhaustive != tt.wantExhaustive {
+				t.Errorf("Exhaustive = %v, want %v", report.Exhaustive, tt.wantExhaustive)
+			}
+			if tt.wantCompatible {
+				if report.CounterExample != nil {
+					t.Errorf("unexpected counter-example %v", report.CounterExample)
+				}
+				return
+			}
+
+			ce := report.CounterExample
+			if ce == nil {
+				t.Fatal("missing counter-example")
+			}
+			if ce.Number != tt.wantNumber {
+				t.Errorf("counter-example number = %d, want %d", ce.Number, tt.wantNumber)
+			}
+
+			// The counter-example must reproduce
+			oldCodec, _ := NewCodec(tt.old)
+			newCodec, _ := NewCodec(tt.new)
+			if word, _ := oldCodec.Encode(ce.Number); word != ce.Word {
+				t.Errorf("counter-example word = %q, old codec encodes %q", ce.Word, word)
+			}
+			decoded, err := newCodec.Decode(ce.Word)
+			if err == nil && decoded == int(ce.Number) {
+				t.Errorf("counter-example %v decodes correctly", ce)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility_CounterExampleString(t *testing.T) {
+	small := PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}
+	old := compatConfig(t, []string{"CVC", "CVCVC"}, small)
+	shrunk := compatConfig(t, []string{"CVC"}, small)
+
+	report, err := CheckCompatibility(old, shrunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Compatible || report.CounterExample == nil {
+		t.Fatal("removing a pattern must be incompatible")
+	}
+	if got := report.CounterExample.String(); !strings.Contains(got, "125") || !strings.Contains(got, "rejects") {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCheckCompatibility_InvalidConfig(t *testing.T) {
+	valid := compatConfig(t, []string{"CVC"}, PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")})
+	if _, err := CheckCompatibility(&Config{}, valid); err == nil {
+		t.Error("expected error for invalid old config")
+	}
+	if _, err := CheckCompatibility(valid, &Config{}); err == nil {
+		t.Error("expected error for invalid new config")
+	}
+}
@@ -19,6 +19,14 @@ type (
 		// Optional: Expected BitWidth for preflight assertion
 		// If set, Validate() will fail if calculated BitWidth doesn't match
 		ExpectedBitWidth int
+
+		// Optional: Encoding algorithm version; 0 selects DefaultEncodingVersion
+		// Validate() resolves it, so the config records the version it encodes with
+		EncodingVersion int
+
+		// Optional: Expected Fingerprint for preflight assertion
+		// If set, Validate() will fail if the calculated Fingerprint doesn't match
+		ExpectedFingerprint string
 	}
 
 	// ConfigOption is a functional option for configuring Config.
@@ -55,27 +63,64 @@ func NewConfigWithOptions(opts ...ConfigOption) (*Config, error) {
 
 // Validate checks if the config values are valid and auto-calculates BitWidth.
 func (c *Config) Validate() error {
+	encoder, err := c.validate()
+	if err != nil {
+		return err
+	}
+
+	// Preflight assertion: check if Fingerprint matches expected value
+	if c.ExpectedFingerprint != "" {
+		fingerprint, err := c.fingerprint(encoder)
+		if err != nil {
+			return err
+		}
+		if fingerprint != c.ExpectedFingerprint {
+			return fmt.Errorf(
+				"preflight assertion failed: calculated Fingerprint is %s, but expected %s\n"+
+					"This indicates a breaking change in the encoding.\n"+
+					"Update ExpectedFingerprint to %s if this change is intentional",
+				fingerprint,
+				c.ExpectedFingerprint,
+				fingerprint,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validate performs all checks except the fingerprint assertion and returns
+// the encoder the config describes.
+func (c *Config) validate() (*PhoneticEncoder, error) {
 	// Ensure required fields are initialized
 	if c.Shuffle == nil {
-		return errors.New("shuffle config is required")
+		return nil, errors.New("shuffle config is required")
 	}
 	if c.Phonetic == nil {
-		return errors.New("phonetic config is required")
+		return nil, errors.New("phonetic config is required")
+	}
+
+	if c.EncodingVersion == 0 {
+		c.EncodingVersion = DefaultEncodingVersion
 	}
+	if err := validateEncodingVersion(c.EncodingVersion); err != nil {
+		return nil, err
+	}
+	c.Shuffle.EncodingVersion = c.EncodingVersion
 
 	// Validate phonetic config first
 	if err := c.Phonetic.Validate(); err != nil {
-		return fmt.Errorf("phonetic config invalid: %w", err)
+		return nil, fmt.Errorf("phonetic config invalid: %w", err)
 	}
 
 	// Create encoder to determine optimal bit width
 	encoder, err := NewPhoneticEncoder(c.Phonetic)
 	if err != nil {
-		return fmt.Errorf("failed to create encoder: %w", err)
+		return nil, fmt.Errorf("failed to create encoder: %w", err)
 	}
 
 	if len(encoder.patternEncoders) == 0 {
-		return errors.New("no valid patterns configured")
+		return nil, errors.New("no valid patterns configured")
 	}
 
 	// Auto-calculate BitWidth from largest pattern's capacity
@@ -84,7 +129,7 @@ func (c *Config) Validate() error {
 
 	// Preflight assertion: check if BitWidth matches expected value
 	if c.ExpectedBitWidth > 0 && c.Shuffle.BitWidth != c.ExpectedBitWidth {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"preflight assertion failed: calculated BitWidth is %d, but expected %d\n"+
 				"This indicates a breaking change in the phonetic configuration.\n"+
 				"Update ExpectedBitWidth to %d if this change is intentional",
@@ -96,10 +141,10 @@ func (c *Config) Validate() error {
 
 	// Validate shuffle config after BitWidth is set
 	if err := c.Shuffle.Validate(); err != nil {
-		return fmt.Errorf("shuffle config invalid: %w", err)
+		return nil, fmt.Errorf("shuffle config invalid: %w", err)
 	}
 
-	return nil
+	return encoder, nil
 }
 
 // WithRounds sets the number of Feistel rounds.
@@ -122,6 +167,36 @@ func WithSeed(seed uint64) ConfigOption {
 	}
 }
 
+// NewShuffler validates the config and creates the shuffler it describes.
+// The shuffler is built from the validated ShuffleConfig, so it cannot
+// disagree with Validate about limits or the auto-calculated BitWidth.
+func (c *Config) NewShuffler() (*FeistelShuffler, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return NewFeistelShufflerWithOptions(*c.Shuffle)
+}
+
+// WithRoundFunction selects the Feistel round function.
+func WithRoundFunction(roundFunction RoundFunction) ConfigOption {
+	return func(c *Config) {
+		if c.Shuffle == nil {
+			c.Shuffle = &ShuffleConfig{}
+		}
+		c.Shuffle.RoundFunction = roundFunction
+	}
+}
+
+// WithKey sets the secret key for keyed round functions.
+func WithKey(key []byte) ConfigOption {
+	return func(c *Config) {
+		if c.Shuffle == nil {
+			c.Shuffle = &ShuffleConfig{}
+		}
+		c.Shuffle.Key = key
+	}
+}
+
 // WithShuffle sets the shuffle configuration.
 func WithShuffle(shuffle *ShuffleConfig) ConfigOption {
 	return func(c *Config) {
@@ -145,6 +220,23 @@ func WithExpectedBitWidth(bitWidth int) ConfigOption {
 	}
 }
 
+// WithEncodingVersion pins the encoding algorithm version, so upgrading the
+// library keeps producing the same words.
+func WithEncodingVersion(version int) ConfigOption {
+	return func(c *Config) {
+		c.EncodingVersion = version
+	}
+}
+
+// WithExpectedFingerprint sets the expected fingerprint for preflight assertion.
+// If the calculated Fingerprint doesn't match, Validate() will fail.
+// Unlike the bit width, it also catches reordered alphabets and changed seeds.
+func WithExpectedFingerprint(fingerprint string) ConfigOption {
+	return func(c *Config) {
+		c.ExpectedFingerprint = fingerprint
+	}
+}
+
 // calculateRequiredBitWidth returns the minimum bit width needed to represent totalCombinations.
 func calculateRequiredBitWidth(totalCombinations int) int {
 	if totalCombinations <= 1 {
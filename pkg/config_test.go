@@ -160,9 +160,10 @@ func TestNewConfigWithOptions(t *testing.T) {
 					},
 				},
 				Shuffle: &ShuffleConfig{
-					BitWidth: 5, // Auto-calculated: 4*2*4 = 32 combinations, needs 5 bits
-					Seed:     12345,
-					Rounds:   3,
+					BitWidth:        5, // Auto-calculated: 4*2*4 = 32 combinations, needs 5 bits
+					Seed:            12345,
+					Rounds:          3,
+					EncodingVersion: DefaultEncodingVersion, // Resolved from the config
 				},
 			},
 			wantErr: false,
@@ -250,3 +251,24 @@ func TestConfig_PreflightAssertion(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_NewShuffler(t *testing.T) {
+	config, err := NewConfigWithOptions(WithRounds(MaxRounds), WithSeed(12345))
+	if err != nil {
+		t.Fatalf("NewConfigWithOptions() error = %v", err)
+	}
+
+	shuffler, err := config.NewShuffler()
+	if err != nil {
+		t.Fatalf("NewShuffler() error = %v", err)
+	}
+	if shuffler.Rounds() != MaxRounds || shuffler.BitWidth() != config.Shuffle.BitWidth {
+		t.Errorf("NewShuffler() rounds=%d bitWidth=%d, want %d, %d",
+			shuffler.Rounds(), shuffler.BitWidth(), MaxRounds, config.Shuffle.BitWidth)
+	}
+
+	config.Shuffle.Rounds = MaxRounds + 1
+	if _, err := config.NewShuffler(); err == nil {
+		t.Error("NewShuffler() should reject rounds beyond MaxRounds")
+	}
+}
@@ -0,0 +1,205 @@
+package phonid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+type (
+	// DesignConstraints describes the patterns DesignPatterns should propose.
+	DesignConstraints struct {
+		Placeholders   PlaceholderMap // Character sets available to the designer
+		TargetBitWidth int            // Largest pattern must hold at least 2^TargetBitWidth values
+
+		MaxLength                int  // 0 means any allowed pattern length
+		StartWithConsonant       bool // First placeholder must not be a vowel
+		MaxConsecutiveConsonants int  // 0 means unlimited
+		IncludeShorter           bool // Add one pattern per shorter allowed length for small numbers
+	}
+
+	// designCandidate is a placeholder arrangement considered by the designer.
+	designCandidate struct {
+		pattern      string
+		combinations uint64
+	}
+)
+
+// DesignPatterns proposes a minimal set of patterns reaching the target bit width.
+// It picks the shortest allowed length that can reach the target and, within that
+// length, the pattern with the fewest combinations. The returned config passes
+// PhonidConfig.Validate and NewPhoneticEncoder.
+func DesignPatterns(constraints DesignConstraints) (*PhonidConfig, error) {
+	if constraints.TargetBitWidth < 1 || constraints.TargetBitWidth >= MaxBitWidth-1 {
+		return nil, fmt.Errorf("target bit width must be between 1 and %d, got %d",
+			MaxBitWidth-2, constraints.TargetBitWidth)
+	}
+	if len(constraints.Placeholders) == 0 {
+		return nil, errors.New("at least one placeholder set is required")
+	}
+
+	target := uint64(1) << constraints.TargetBitWidth
+
+	var shorter []designCandidate
+	for _, length := range AllowedPatternLengths {
+		if constraints.MaxLength > 0 && length > constraints.MaxLength {
+			break
+		}
+
+		candidates := constraints.candidates(length)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		// Tightest fit first; ties are broken by pattern for stable output
+		slices.SortFunc(candidates, func(a, b designCandidate) int {
+			if a.combinations != b.combinations {
+				if a.combinations < b.combinations {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.pattern, b.pattern)
+		})
+
+		for _, c := range candidates {
+			if c.combinations >= target {
+				return constraints.assemble(shorter, c)
+			}
+		}
+
+		// Nothing reaches the target at this length; remember the roomiest pattern
+		shorter = append(shorter, candidates[len(candidates)-1])
+	}
+
+	return nil, fmt.Errorf("no pattern of an allowed length reaches %d bits under the given constraints",
+		constraints.TargetBitWidth)
+}
+
+// assemble builds the final config from the chosen pattern and shorter fallbacks.
+func (dc DesignConstraints) assemble(shorter []designCandidate, chosen designCandidate) (*PhonidConfig, error) {
+	patterns := make([]string, 0, len(shorter)+1)
+	if dc.IncludeShorter {
+		seen := make(map[uint64]bool)
+		for _, c := range shorter {
+			// Distinct totalCombinations are required by the encoder
+			if seen[c.combinations] {
+				continue
+			}
+			seen[c.combinations] = true
+			patterns = append(patterns, c.pattern)
+		}
+	}
+	patterns = append(patterns, chosen.pattern)
+
+	config := &PhonidConfig{
+		Patterns:     patterns,
+		Placeholders: dc.Placeholders,
+	}
+	if _, err := NewPhoneticEncoder(config); err != nil {
+		return nil, fmt.Errorf("designed config is invalid: %w", err)
+	}
+	return config, nil
+}
+
+// candidates returns every valid placeholder composition of the given length.
+// Only one arrangement per composition is produced, since capacity depends on
+// placeholder counts alone.
+func (dc DesignConstraints) candidates(length int) []designCandidate {
+	keys := make([]PlaceholderType, 0, len(dc.Placeholders))
+	for key := range dc.Placeholders {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	// Placeholders come from a plain PlaceholderMap, so merging cannot fail
+	symbols, _ := (&PhonidConfig{Placeholders: dc.Placeholders}).symbols()
+
+	var result []designCandidate
+	counts := make([]int, len(keys))
+
+	var walk func(index, remaining int)
+	walk = func(index, remaining int) {
+		if index == len(keys)-1 {
+			counts[index] = remaining
+			if c, ok := dc.candidate(keys, counts, symbols); ok {
+				result = append(result, c)
+			}
+			return
+		}
+		for n := 0; n <= remaining; n++ {
+			counts[index] = n
+			walk(index+1, remaining-n)
+		}
+	}
+	walk(0, length)
+
+	return result
+}
+
+// candidate arranges a composition into a pattern honoring the constraints.
+func (dc DesignConstraints) candidate(keys []PlaceholderType, counts []int, symbols symbolMap) (designCandidate, bool) {
+	var vowels, consonants []PlaceholderType
+	combinations := uint64(1)
+
+	for i, key := range keys {
+		for range counts[i] {
+			if key == Vowel {
+				vowels = append(vowels, key)
+			} else {
+				consonants = append(consonants, key)
+			}
+
+			hi, lo := bits.Mul64(combinations, uint64(len(dc.Placeholders[key])))
+			if hi != 0 || lo > math.MaxInt64 {
+				return designCandidate{}, false // Would overflow PatternEncoder capacity
+			}
+			combinations = lo
+		}
+	}
+
+	pattern, ok := dc.arrange(vowels, consonants)
+	if !ok {
+		return designCandidate{}, false
+	}
+
+	if err := validatePattern(pattern, symbols); err != nil {
+		return designCandidate{}, false
+	}
+
+	return designCandidate{pattern: pattern, combinations: combinations}, true
+}
+
+// arrange spreads consonants as evenly as possible into the gaps around vowels,
+// which minimizes the longest consonant run. The first gap is always filled
+// when there is at least one consonant.
+func (dc DesignConstraints) arrange(vowels, consonants []PlaceholderType) (string, bool) {
+	gaps := len(vowels) + 1
+	longest := (len(consonants) + gaps - 1) / gaps
+
+	if dc.MaxConsecutiveConsonants > 0 && longest > dc.MaxConsecutiveConsonants {
+		return "", false
+	}
+	if dc.StartWithConsonant && len(consonants) == 0 {
+		return "", false
+	}
+
+	var sb strings.Builder
+	next := 0
+	for gap := range gaps {
+		// ceil((gap+1)*c/gaps) - ceil(gap*c/gaps) consonants go into this gap
+		size := ((gap+1)*len(consonants)+gaps-1)/gaps - (gap*len(consonants)+gaps-1)/gaps
+		for range size {
+			sb.WriteRune(rune(consonants[next]))
+			next++
+		}
+		if gap < len(vowels) {
+			sb.WriteRune(rune(vowels[gap]))
+		}
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,111 @@
+package phonid_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestDesignPatterns(t *testing.T) {
+	placeholders := PlaceholderMap{
+		Consonant: RuneSet("bcdfghjkpqstvwxz"),
+		Liquid:    RuneSet("lmnr"),
+		Vowel:     RuneSet("aeiou"),
+	}
+
+	tests := []struct {
+		name         string
+		constraints  DesignConstraints
+		wantPatterns []string
+		wantErr      bool
+	}{
+		{
+			name:         "16 bits fit in five characters",
+			constraints:  DesignConstraints{Placeholders: placeholders, TargetBitWidth: 16},
+			wantPatterns: []string{"CCVCL"},
+		},
+		{
+			name: "32 bits with at most two consonants in a row",
+			constraints: DesignConstraints{
+				Placeholders:             placeholders,
+				TargetBitWidth:           32,
+				StartWithConsonant:       true,
+				MaxConsecutiveConsonants: 2,
+			},
+			wantPatterns: []string{"CVVCVVCVVCV"},
+		},
+		{
+			name: "shorter patterns included",
+			constraints: DesignConstraints{
+				Placeholders:   placeholders,
+				TargetBitWidth: 20,
+				IncludeShorter: true,
+			},
+			wantPatterns: []string{"CVC", "CCVCC", "CCCVLLL"},
+		},
+		{
+			name:        "unreachable within max length",
+			constraints: DesignConstraints{Placeholders: placeholders, TargetBitWidth: 32, MaxLength: 7},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid target",
+			constraints: DesignConstraints{Placeholders: placeholders, TargetBitWidth: 0},
+			wantErr:     true,
+		},
+		{
+			name:        "no placeholders",
+			constraints: DesignConstraints{TargetBitWidth: 16},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DesignPatterns(tt.constraints)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DesignPatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(got.Patterns, tt.wantPatterns) {
+				t.Errorf("Patterns = %v, want %v", got.Patterns, tt.wantPatterns)
+			}
+
+			encoder, err := NewPhoneticEncoder(got)
+			if err != nil {
+				t.Fatalf("NewPhoneticEncoder() error = %v", err)
+			}
+			if bw := encoder.Stats().BitWidth; bw < tt.constraints.TargetBitWidth {
+				t.Errorf("BitWidth = %d, want at least %d", bw, tt.constraints.TargetBitWidth)
+			}
+		})
+	}
+}
+
+func TestDesignPatterns_HonorsConstraints(t *testing.T) {
+	got, err := DesignPatterns(DesignConstraints{
+		Placeholders:             DefaultPlaceholders,
+		TargetBitWidth:           40,
+		StartWithConsonant:       true,
+		MaxConsecutiveConsonants: 1,
+	})
+	if err != nil {
+		t.Fatalf("DesignPatterns() error = %v", err)
+	}
+
+	for _, pattern := range got.Patterns {
+		if pattern[0] == byte(Vowel) {
+			t.Errorf("pattern %q starts with a vowel", pattern)
+		}
+		for _, run := range strings.Split(pattern, string(Vowel)) {
+			if len(run) > 1 {
+				t.Errorf("pattern %q has consonant run %q", pattern, run)
+			}
+		}
+	}
+}
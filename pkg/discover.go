@@ -0,0 +1,132 @@
+package phonid
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// XDGConfigDir is the directory below $XDG_CONFIG_HOME searched last by FindPhonidRC.
+const XDGConfigDir = "phonid"
+
+// vcsMarkers identify a repository root, where the upward search stops.
+var vcsMarkers = []string{".git", ".hg", ".svn", ".jj"}
+
+// FindPhonidRC looks for an rc file the way git looks for its config.
+// Starting at startDir it checks each directory, moving to the parent until a
+// directory containing a VCS marker (.git, .hg, .svn, .jj) or the filesystem
+// root has been searched. Within a directory the precedence is:
+//
+//  1. .<prefix>.phonidrc[.toml|.yaml|.json] (only if prefix is set)
+//  2. .phonidrc[.toml|.yaml|.json]
+//
+// A closer directory always wins over a parent. If nothing is found, the same
+// names are looked up in $XDG_CONFIG_HOME/phonid/ (default ~/.config/phonid/).
+// Two spellings of the same name in one directory are rejected as ambiguous.
+func FindPhonidRC(startDir, prefix string) (string, error) {
+	names, err := rcCandidateNames(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", startDir, err)
+	}
+
+	for {
+		found, err := findInDir(dir, names)
+		if err != nil || found != "" {
+			return found, err
+		}
+
+		parent := filepath.Dir(dir)
+		if isVCSRoot(dir) || parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if configHome := xdgConfigHome(); configHome != "" {
+		found, err := findInDir(filepath.Join(configHome, XDGConfigDir), names)
+		if err != nil || found != "" {
+			return found, err
+		}
+	}
+
+	return "", fmt.Errorf("no phonidrc found from %s upwards or in $XDG_CONFIG_HOME/%s: %w",
+		startDir, XDGConfigDir, fs.ErrNotExist)
+}
+
+// rcCandidateNames returns groups of file names; all names in a group are
+// spellings of the same config and must not coexist.
+func rcCandidateNames(prefix string) ([][]string, error) {
+	bases := []string{RcFileName}
+	if prefix != "" {
+		prefixed := "." + prefix + RcFileName
+		if !IsValidPhonidRCFilename(prefixed) {
+			return nil, fmt.Errorf("invalid prefix %q: must not contain dots or path separators", prefix)
+		}
+		bases = []string{prefixed, RcFileName}
+	}
+
+	groups := make([][]string, 0, len(bases))
+	for _, base := range bases {
+		group := make([]string, 0, len(rcFileSuffixes))
+		for _, suffix := range rcFileSuffixes {
+			group = append(group, base+suffix)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// findInDir returns the first existing candidate in dir, or "" if there is none.
+func findInDir(dir string, groups [][]string) (string, error) {
+	for _, group := range groups {
+		var found []string
+		for _, name := range group {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				found = append(found, path)
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			return "", fmt.Errorf("ambiguous phonidrc in %s: %v", dir, found)
+		}
+	}
+	return "", nil
+}
+
+// isVCSRoot reports whether dir contains a version control marker.
+func isVCSRoot(dir string) bool {
+	for _, marker := range vcsMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			// Unreadable markers still indicate a repository boundary
+			return true
+		}
+	}
+	return false
+}
+
+// xdgConfigHome returns $XDG_CONFIG_HOME, falling back to ~/.config.
+func xdgConfigHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config")
+}
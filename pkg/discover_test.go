@@ -0,0 +1,81 @@
+package phonid_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestFindPhonidRC(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "services", "orders")
+	xdg := filepath.Join(root, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.MkdirAll(nested, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	// Nothing above the repository root is considered
+	touch(t, filepath.Join(root, ".phonidrc"))
+	if _, err := FindPhonidRC(nested, ""); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FindPhonidRC() error = %v, want fs.ErrNotExist", err)
+	}
+
+	steps := []struct {
+		create string
+		prefix string
+		want   string
+	}{
+		{filepath.Join(xdg, "phonid", ".phonidrc.toml"), "", filepath.Join(xdg, "phonid", ".phonidrc.toml")},
+		{filepath.Join(repo, ".phonidrc"), "", filepath.Join(repo, ".phonidrc")},
+		{filepath.Join(repo, ".orders.phonidrc.toml"), "orders", filepath.Join(repo, ".orders.phonidrc.toml")},
+		{filepath.Join(repo, ".orders.phonidrc.toml"), "", filepath.Join(repo, ".phonidrc")},
+		{filepath.Join(nested, ".phonidrc.toml"), "orders", filepath.Join(nested, ".phonidrc.toml")},
+	}
+
+	for _, step := range steps {
+		touch(t, step.create)
+		got, err := FindPhonidRC(nested, step.prefix)
+		if err != nil {
+			t.Fatalf("FindPhonidRC(prefix=%q) error = %v", step.prefix, err)
+		}
+		if got != step.want {
+			t.Errorf("after creating %s: FindPhonidRC(prefix=%q) = %s, want %s",
+				step.create, step.prefix, got, step.want)
+		}
+	}
+}
+
+func TestFindPhonidRCInvalid(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+
+	touch(t, filepath.Join(dir, ".phonidrc"))
+	touch(t, filepath.Join(dir, ".phonidrc.toml"))
+	if _, err := FindPhonidRC(dir, ""); err == nil {
+		t.Error("FindPhonidRC() should reject two spellings in one directory")
+	}
+
+	if _, err := FindPhonidRC(dir, "a.b"); err == nil {
+		t.Error("FindPhonidRC() should reject prefixes with dots")
+	}
+}
@@ -3,8 +3,8 @@ package phonid
 import (
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 type (
@@ -20,12 +20,18 @@ type (
 		positions         []Position
 		totalCombinations PositiveInt
 		length            int // Number of positions/characters in the pattern
+		minRunes          int // Shortest possible word in runes
+		maxRunes          int // Longest possible word in runes
+
+		// Set only when phonotactic rules apply; see applyPhonotactics
+		transitions  [][][]bool
+		suffixCounts [][]int
 	}
 
 	// Position represents one character position in the pattern.
 	Position struct {
 		placeholder string
-		chars       []rune
+		symbols     []string // Single runes or prefix-free syllables
 		base        int
 	}
 )
@@ -41,13 +47,14 @@ func NewPhoneticEncoder(config *PhonidConfig) (*PhoneticEncoder, error) {
 }
 
 // buildPatternEncoder creates a PatternEncoder from a pattern string and placeholders.
-func buildPatternEncoder(pattern string, placeholders PlaceholderMap) (*PatternEncoder, error) {
+func buildPatternEncoder(pattern string, placeholders symbolMap) (*PatternEncoder, error) {
 	if pattern == "" {
 		return nil, errors.New("pattern cannot be empty")
 	}
 
 	positions := make([]Position, 0, len(pattern))
 	totalCombinations := 1
+	minRunes, maxRunes := 0, 0
 
 	// Parse each character in the pattern
 	for i, char := range pattern {
@@ -70,10 +77,14 @@ func buildPatternEncoder(pattern string, placeholders PlaceholderMap) (*PatternE
 		// Create position
 		position := Position{
 			placeholder: string(char),
-			chars:       []rune(chars),
+			symbols:     chars,
 			base:        len(chars),
 		}
 
+		shortest, longest := symbolRuneRange(chars)
+		minRunes += shortest
+		maxRunes += longest
+
 		positions = append(positions, position)
 		totalCombinations *= position.base
 	}
@@ -83,6 +94,8 @@ func buildPatternEncoder(pattern string, placeholders PlaceholderMap) (*PatternE
 		positions:         positions,
 		totalCombinations: PositiveInt(totalCombinations),
 		length:            len(positions),
+		minRunes:          minRunes,
+		maxRunes:          maxRunes,
 	}, nil
 }
 
@@ -90,11 +103,20 @@ func buildPatternEncoder(pattern string, placeholders PlaceholderMap) (*PatternE
 func newPhoneticEncoder(config *PhonidConfig) (*PhoneticEncoder, error) {
 	patternEncoders := make([]*PatternEncoder, 0, len(config.Patterns))
 
+	symbols, err := config.symbols()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, pattern := range config.Patterns {
-		encoder, err := buildPatternEncoder(pattern, config.Placeholders)
+		encoder, err := buildPatternEncoder(pattern, symbols)
 		if err != nil {
 			return nil, err
 		}
+		encoder.permute(config.Permutation)
+		if err := encoder.applyPhonotactics(&config.Phonotactics); err != nil {
+			return nil, err
+		}
 		patternEncoders = append(patternEncoders, encoder)
 	}
 
@@ -119,6 +141,11 @@ func newPhoneticEncoder(config *PhonidConfig) (*PhoneticEncoder, error) {
 		}
 	}
 
+	// Syllables may let words of different patterns collide
+	if err := validateUniqueParsing(patternEncoders); err != nil {
+		return nil, err
+	}
+
 	return &PhoneticEncoder{
 		config:          config,
 		patternEncoders: patternEncoders,
@@ -144,16 +171,31 @@ func (e *PhoneticEncoder) Encode(number PositiveInt) (string, error) {
 }
 
 func (e *PhoneticEncoder) Decode(word string) (int, error) {
-	wordRunes := []rune(word)
+	wordLength := utf8.RuneCountInString(word)
 
-	// Try to match pattern by length
+	// Narrow down patterns by length
+	candidates := make([]*PatternEncoder, 0, len(e.patternEncoders))
 	for _, pattern := range e.patternEncoders {
-		if len(wordRunes) == pattern.length {
-			return pattern.Decode(word)
+		if wordLength >= pattern.minRunes && wordLength <= pattern.maxRunes {
+			candidates = append(candidates, pattern)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, fmt.Errorf("word length %d doesn't match any pattern", wordLength)
+	case 1:
+		return candidates[0].Decode(word)
+	}
+
+	// Patterns are validated to never share a word, so at most one succeeds
+	for _, pattern := range candidates {
+		if number, err := pattern.Decode(word); err == nil {
+			return number, nil
 		}
 	}
 
-	return 0, fmt.Errorf("word length %d doesn't match any pattern", len(wordRunes))
+	return 0, fmt.Errorf("word %q doesn't match any pattern", word)
 }
 
 // Encode converts a number to a phonetic word.
@@ -162,7 +204,11 @@ func (e *PatternEncoder) Encode(number PositiveInt) (string, error) {
 		return "", fmt.Errorf("number %d exceeds maximum %d", number, e.totalCombinations-1)
 	}
 
-	var result strings.Builder
+	if e.suffixCounts != nil {
+		return e.unrank(number), nil
+	}
+
+	symbols := make([]string, len(e.positions))
 	remaining := int(number)
 
 	// Convert to mixed-radix representation (right-to-left)
@@ -171,41 +217,59 @@ func (e *PatternEncoder) Encode(number PositiveInt) (string, error) {
 		charIndex := remaining % position.base
 		remaining /= position.base
 
-		result.WriteRune(position.chars[charIndex])
+		symbols[i] = position.symbols[charIndex]
 	}
 
-	// Reverse the string since we built it backwards
-	word := result.String()
-	return reverseString(word), nil
+	return strings.Join(symbols, ""), nil
 }
 
 // Decode converts a phonetic word back to a number.
 func (e *PatternEncoder) Decode(word string) (int, error) {
-	runes := []rune(word)
-	if len(runes) != len(e.positions) {
-		return 0, fmt.Errorf(
-			"word length %d doesn't match pattern length %d",
-			len(runes),
-			len(e.positions),
-		)
+	indices, err := e.parse(word)
+	if err != nil {
+		return 0, err
+	}
+
+	if e.suffixCounts != nil {
+		return e.rank(indices)
 	}
 
 	var result int
 
-	for i, r := range runes {
-		position := e.positions[i]
+	// Add to result using positional notation
+	for i, charIndex := range indices {
+		result = result*e.positions[i].base + charIndex
+	}
 
-		// Find character index in this position's alphabet
+	return result, nil
+}
+
+// parse splits a word into per-position symbol indices. Symbol sets are
+// prefix-free, so at most one symbol can match at each position.
+func (e *PatternEncoder) parse(word string) ([]int, error) {
+	if e.minRunes == e.maxRunes && utf8.RuneCountInString(word) != e.minRunes {
+		return nil, fmt.Errorf(
+			"word length %d doesn't match pattern length %d",
+			utf8.RuneCountInString(word),
+			e.minRunes,
+		)
+	}
+
+	indices := make([]int, len(e.positions))
+	rest := word
+
+	for i, position := range e.positions {
 		charIndex := -1
-		for idx, char := range position.chars {
-			if char == r {
+		for idx, symbol := range position.symbols {
+			if strings.HasPrefix(rest, symbol) {
 				charIndex = idx
 				break
 			}
 		}
 
 		if charIndex == -1 {
-			return 0, fmt.Errorf(
+			r, _ := utf8.DecodeRuneInString(rest)
+			return nil, fmt.Errorf(
 				"character '%c' at position %d is not valid for placeholder '%s'",
 				r,
 				i,
@@ -213,26 +277,18 @@ func (e *PatternEncoder) Decode(word string) (int, error) {
 			)
 		}
 
-		// Add to result using positional notation
-		multiplier := int(1)
-		for j := i + 1; j < len(e.positions); j++ {
-			multiplier *= e.positions[j].base
-		}
+		indices[i] = charIndex
+		rest = rest[len(position.symbols[charIndex]):]
+	}
 
-		result += charIndex * multiplier
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected trailing characters %q after pattern '%s'", rest, e.pattern)
 	}
 
-	return result, nil
+	return indices, nil
 }
 
 // MaxValue returns the maximum number that can be encoded.
 func (e *PatternEncoder) MaxValue() int {
 	return int(e.totalCombinations) - 1
 }
-
-// reverseString reverses a string.
-func reverseString(s string) string {
-	runes := []rune(s)
-	slices.Reverse(runes)
-	return string(runes)
-}
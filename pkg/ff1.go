@@ -0,0 +1,376 @@
+package phonid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// FF1MaxRadix is the largest radix permitted by NIST SP 800-38G.
+	FF1MaxRadix = 1 << 16
+	// FF1MinDomain is the smallest domain (radix^length) permitted by NIST SP 800-38G Rev. 1.
+	FF1MinDomain = 1_000_000
+
+	ff1Rounds    = 10
+	ff1BlockSize = aes.BlockSize
+)
+
+type (
+	// Shuffler is a keyed bijection on [0, MaxValue()].
+	// FeistelShuffler and FF1Shuffler both implement it.
+	Shuffler interface {
+		Encode(input uint64) (uint64, error)
+		Decode(encoded uint64) (uint64, error)
+		MaxValue() uint64
+	}
+
+	// FF1 is the NIST SP 800-38G FF1 format-preserving cipher over numeral
+	// strings of a fixed radix, using AES-128, AES-192 or AES-256.
+	FF1 struct {
+		block cipher.Block
+		tweak []byte
+		radix int
+	}
+
+	// FF1Shuffler applies FF1 to numbers in [0, radix^length). A shuffler
+	// created for a capacity below the domain cycle-walks its outputs back
+	// into [0, capacity), so every output is a valid word.
+	//
+	// FF1 is not selectable from Config or Codec: rc files, Fingerprint and
+	// the compatibility checks describe a seeded Feistel network, while FF1
+	// takes an AES key that must not be stored in configuration. Pair the
+	// shuffler with a PhoneticEncoder instead.
+	FF1Shuffler struct {
+		cipher   *FF1
+		length   int
+		maxValue uint64
+	}
+)
+
+var _ Shuffler = (*FeistelShuffler)(nil)
+var _ Shuffler = (*FF1Shuffler)(nil)
+
+// NewFF1 creates an FF1 cipher. The key selects the AES variant by its length
+// (16, 24 or 32 bytes); the tweak is public and may be empty.
+func NewFF1(key, tweak []byte, radix int) (*FF1, error) {
+	if radix < 2 || radix > FF1MaxRadix {
+		return nil, fmt.Errorf("radix must be between 2 and %d, got %d", FF1MaxRadix, radix)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FF1 key: %w", err)
+	}
+
+	return &FF1{
+		block: block,
+		tweak: append([]byte(nil), tweak...),
+		radix: radix,
+	}, nil
+}
+
+// Encrypt enciphers a numeral string. Each numeral must be below the radix.
+func (f *FF1) Encrypt(numerals []uint16) ([]uint16, error) {
+	return f.crypt(numerals, true)
+}
+
+// Decrypt deciphers a numeral string produced by Encrypt.
+func (f *FF1) Decrypt(numerals []uint16) ([]uint16, error) {
+	return f.crypt(numerals, false)
+}
+
+// crypt converts numerals to halves, runs the rounds and converts back.
+func (f *FF1) crypt(numerals []uint16, encrypt bool) ([]uint16, error) {
+	n := len(numerals)
+	if err := f.validateLength(n); err != nil {
+		return nil, err
+	}
+	for _, numeral := range numerals {
+		if int(numeral) >= f.radix {
+			return nil, fmt.Errorf("numeral %d is out of range for radix %d", numeral, f.radix)
+		}
+	}
+
+	u := n / 2
+	a, b := f.num(numerals[:u]), f.num(numerals[u:])
+	a, b = f.rounds(a, b, n, encrypt)
+
+	return append(f.str(a, u), f.str(b, n-u)...), nil
+}
+
+// validateLength enforces the NIST domain size requirement.
+func (f *FF1) validateLength(n int) error {
+	if n < 2 {
+		return fmt.Errorf("FF1 input must have at least 2 numerals, got %d", n)
+	}
+	domain := new(big.Int).Exp(big.NewInt(int64(f.radix)), big.NewInt(int64(n)), nil)
+	if domain.Cmp(big.NewInt(FF1MinDomain)) < 0 {
+		return fmt.Errorf("FF1 domain %d^%d is below the minimum of %d", f.radix, n, FF1MinDomain)
+	}
+	return nil
+}
+
+// rounds implements steps 1-7 of Algorithms 7 (encrypt) and 8 (decrypt)
+// on the numeric values of the halves A (u numerals) and B (v numerals).
+func (f *FF1) rounds(a, b *big.Int, n int, encrypt bool) (*big.Int, *big.Int) {
+	u, v := n/2, n-n/2
+	radix := big.NewInt(int64(f.radix))
+
+	// b: bytes needed for radix^v, d: bytes of pseudo-random output per round
+	byteLen := (new(big.Int).Sub(new(big.Int).Exp(radix, big.NewInt(int64(v)), nil), big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((byteLen+3)/4) + 4
+
+	p := f.header(u, n)
+	moduli := [2]*big.Int{
+		new(big.Int).Exp(radix, big.NewInt(int64(u)), nil),
+		new(big.Int).Exp(radix, big.NewInt(int64(v)), nil),
+	}
+
+	for step := range ff1Rounds {
+		i := step
+		if !encrypt {
+			i = ff1Rounds - 1 - step
+		}
+
+		// The round input is B when encrypting and A when decrypting
+		input := b
+		if !encrypt {
+			input = a
+		}
+		y := f.roundValue(p, i, input, byteLen, d)
+		modulus := moduli[i%2]
+
+		c := new(big.Int)
+		if encrypt {
+			c.Add(a, y).Mod(c, modulus)
+			a, b = b, c
+		} else {
+			c.Sub(b, y).Mod(c, modulus)
+			b, a = a, c
+		}
+	}
+
+	return a, b
+}
+
+// header builds the fixed block P.
+func (f *FF1) header(u, n int) []byte {
+	t := len(f.tweak)
+	return []byte{
+		1, 2, 1,
+		byte(f.radix >> 16), byte(f.radix >> 8), byte(f.radix),
+		ff1Rounds,
+		byte(u),
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t),
+	}
+}
+
+// roundValue computes y = NUM(S) for round i.
+func (f *FF1) roundValue(p []byte, i int, input *big.Int, byteLen, d int) *big.Int {
+	// Q = T || 0^((-t-b-1) mod 16) || [i] || [NUM(input)]^b
+	padding := ((-len(f.tweak)-byteLen-1)%ff1BlockSize + ff1BlockSize) % ff1BlockSize
+	q := make([]byte, 0, len(f.tweak)+padding+1+byteLen)
+	q = append(q, f.tweak...)
+	q = append(q, make([]byte, padding)...)
+	q = append(q, byte(i))
+	q = append(q, make([]byte, byteLen)...)
+	input.FillBytes(q[len(q)-byteLen:])
+
+	r := f.prf(append(append([]byte(nil), p...), q...))
+
+	// S = R || CIPH(R xor [1]) || CIPH(R xor [2]) ... truncated to d bytes
+	s := append([]byte(nil), r...)
+	for j := 1; len(s) < d; j++ {
+		block := append([]byte(nil), r...)
+		for k := range 8 {
+			block[ff1BlockSize-1-k] ^= byte(uint64(j) >> (8 * k))
+		}
+		f.block.Encrypt(block, block)
+		s = append(s, block...)
+	}
+
+	return new(big.Int).SetBytes(s[:d])
+}
+
+// prf is CBC-MAC with a zero IV over whole blocks.
+func (f *FF1) prf(data []byte) []byte {
+	y := make([]byte, ff1BlockSize)
+	for offset := 0; offset < len(data); offset += ff1BlockSize {
+		for k := range ff1BlockSize {
+			y[k] ^= data[offset+k]
+		}
+		f.block.Encrypt(y, y)
+	}
+	return y
+}
+
+// num returns the value of a big-endian numeral string.
+func (f *FF1) num(numerals []uint16) *big.Int {
+	radix := big.NewInt(int64(f.radix))
+	x := new(big.Int)
+	for _, numeral := range numerals {
+		x.Mul(x, radix).Add(x, big.NewInt(int64(numeral)))
+	}
+	return x
+}
+
+// str returns the big-endian numeral string of length m for x.
+func (f *FF1) str(x *big.Int, m int) []uint16 {
+	radix := big.NewInt(int64(f.radix))
+	numerals := make([]uint16, m)
+	rest, digit := new(big.Int).Set(x), new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		rest.DivMod(rest, radix, digit)
+		// #nosec G115 -- digit is below radix <= 2^16
+		numerals[i] = uint16(digit.Uint64())
+	}
+	return numerals
+}
+
+// NewFF1Shuffler creates a shuffler on [0, radix^length), which must fit in a uint64.
+func NewFF1Shuffler(key, tweak []byte, radix, length int) (*FF1Shuffler, error) {
+	f, err := NewFF1(key, tweak, radix)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.validateLength(length); err != nil {
+		return nil, err
+	}
+
+	maxValue := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(length)), nil)
+	maxValue.Sub(maxValue, big.NewInt(1))
+	if !maxValue.IsUint64() {
+		return nil, fmt.Errorf("FF1 domain %d^%d exceeds 64 bits", radix, length)
+	}
+
+	return &FF1Shuffler{
+		cipher:   f,
+		length:   length,
+		maxValue: maxValue.Uint64(),
+	}, nil
+}
+
+// NewFF1ShufflerForCapacity creates a shuffler on [0, capacity). FF1 runs on
+// the smallest domain radix^length >= capacity, preferring the smallest radix
+// among equal domains, and outputs outside the capacity are cycle-walked back
+// into range as Codec does. Perfect powers such as proquint's 2^32 need no
+// walking. The capacity itself must meet FF1MinDomain.
+func NewFF1ShufflerForCapacity(key, tweak []byte, capacity uint64) (*FF1Shuffler, error) {
+	if capacity < FF1MinDomain {
+		return nil, fmt.Errorf("capacity %d is below the FF1 minimum domain of %d; "+
+			"adjust the patterns or use the Feistel shuffler", capacity, FF1MinDomain)
+	}
+	radix, length, ok := smallestDomain(capacity)
+	if !ok {
+		return nil, fmt.Errorf("capacity %d has no FF1 domain radix^length within 64 bits", capacity)
+	}
+
+	shuffler, err := NewFF1Shuffler(key, tweak, radix, length)
+	if err != nil {
+		return nil, err
+	}
+	shuffler.maxValue = capacity - 1
+	return shuffler, nil
+}
+
+// NewFF1ShufflerForEncoder creates a shuffler over the capacity of the
+// encoder's largest pattern.
+func NewFF1ShufflerForEncoder(key, tweak []byte, encoder *PhoneticEncoder) (*FF1Shuffler, error) {
+	if len(encoder.patternEncoders) == 0 {
+		return nil, errors.New("no valid patterns configured")
+	}
+	largest := encoder.patternEncoders[len(encoder.patternEncoders)-1]
+	// #nosec G115 -- totalCombinations is positive
+	return NewFF1ShufflerForCapacity(key, tweak, uint64(largest.totalCombinations))
+}
+
+// smallestDomain finds the smallest radix^length >= capacity with
+// length >= 2, radix <= FF1MaxRadix and a domain that fits in a uint64.
+// Among equal domains the smallest radix wins, so perfect powers keep the
+// radix they were always encrypted with.
+func smallestDomain(capacity uint64) (int, int, bool) {
+	target := new(big.Int).SetUint64(capacity)
+	var best *big.Int
+	bestRadix, bestLength := 0, 0
+
+	for length := MaxBitWidth; length >= 2; length-- {
+		exponent := big.NewInt(int64(length))
+		// Float roots can be off by one; settle the ceiling root exactly
+		radix := max(int64(math.Ceil(math.Pow(float64(capacity), 1/float64(length)))), 2)
+		for radix > 2 && new(big.Int).Exp(big.NewInt(radix-1), exponent, nil).Cmp(target) >= 0 {
+			radix--
+		}
+		domain := new(big.Int).Exp(big.NewInt(radix), exponent, nil)
+		for domain.Cmp(target) < 0 {
+			radix++
+			domain.Exp(big.NewInt(radix), exponent, nil)
+		}
+
+		if radix > FF1MaxRadix || !new(big.Int).Sub(domain, big.NewInt(1)).IsUint64() {
+			continue
+		}
+		if best == nil || domain.Cmp(best) < 0 {
+			// #nosec G115 -- radix <= FF1MaxRadix
+			best, bestRadix, bestLength = domain, int(radix), length
+		}
+	}
+
+	return bestRadix, bestLength, best != nil
+}
+
+// Encode enciphers input, which must not exceed MaxValue.
+func (s *FF1Shuffler) Encode(input uint64) (uint64, error) {
+	return s.walk(input, true)
+}
+
+// Decode deciphers a value produced by Encode.
+func (s *FF1Shuffler) Decode(encoded uint64) (uint64, error) {
+	return s.walk(encoded, false)
+}
+
+// walk applies FF1 until the result lies in [0, MaxValue].
+func (s *FF1Shuffler) walk(value uint64, encrypt bool) (uint64, error) {
+	if value > s.maxValue {
+		return 0, fmt.Errorf("value %d exceeds FF1 domain (max: %d)", value, s.maxValue)
+	}
+	return cycleWalk(func(v uint64) (uint64, error) {
+		return s.crypt(v, encrypt)
+	}, value, s.maxValue)
+}
+
+// crypt runs FF1 on the numeral representation of value.
+func (s *FF1Shuffler) crypt(value uint64, encrypt bool) (uint64, error) {
+	numerals := s.cipher.str(new(big.Int).SetUint64(value), s.length)
+
+	var err error
+	if encrypt {
+		numerals, err = s.cipher.Encrypt(numerals)
+	} else {
+		numerals, err = s.cipher.Decrypt(numerals)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return s.cipher.num(numerals).Uint64(), nil
+}
+
+// MaxValue returns the largest value Encode accepts and returns.
+func (s *FF1Shuffler) MaxValue() uint64 {
+	return s.maxValue
+}
+
+// Radix returns the numeral radix FF1 operates on.
+func (s *FF1Shuffler) Radix() int {
+	return s.cipher.radix
+}
+
+// Length returns the number of numerals per value.
+func (s *FF1Shuffler) Length() int {
+	return s.length
+}
@@ -0,0 +1,255 @@
+package phonid_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+const (
+	nistKey128 = "2B7E151628AED2A6ABF7158809CF4F3C"
+	nistKey192 = "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F"
+	nistKey256 = "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94"
+)
+
+func numerals(t *testing.T, s string, radix int) []uint16 {
+	t.Helper()
+	result := make([]uint16, len(s))
+	for i, r := range s {
+		var digit int
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r >= 'a' && r <= 'z':
+			digit = int(r-'a') + 10
+		}
+		if digit >= radix {
+			t.Fatalf("numeral %q out of range for radix %d", r, radix)
+		}
+		result[i] = uint16(digit)
+	}
+	return result
+}
+
+func numeralString(x []uint16) string {
+	const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+	result := make([]byte, len(x))
+	for i, digit := range x {
+		result[i] = digits[digit]
+	}
+	return string(result)
+}
+
+// NIST SP 800-38G FF1 samples.
+func TestFF1NISTVectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		tweak      string
+		radix      int
+		plaintext  string
+		ciphertext string
+	}{
+		{"sample 1", nistKey128, "", 10, "0123456789", "2433477484"},
+		{"sample 2", nistKey128, "39383736353433323130", 10, "0123456789", "6124200773"},
+		{"sample 3", nistKey128, "3737373770717273373737", 36, "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+		{"sample 4", nistKey192, "", 10, "0123456789", "2830668132"},
+		{"sample 5", nistKey192, "39383736353433323130", 10, "0123456789", "2496655549"},
+		{"sample 6", nistKey192, "3737373770717273373737", 36, "0123456789abcdefghi", "xbj3kv35jrawxv32ysr"},
+		{"sample 7", nistKey256, "", 10, "0123456789", "6657667009"},
+		{"sample 8", nistKey256, "39383736353433323130", 10, "0123456789", "1001623463"},
+		{"sample 9", nistKey256, "3737373770717273373737", 36, "0123456789abcdefghi", "xs8a0azh2avyalyzuwd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, _ := hex.DecodeString(tt.key)
+			tweak, _ := hex.DecodeString(tt.tweak)
+
+			cipher, err := NewFF1(key, tweak, tt.radix)
+			if err != nil {
+				t.Fatalf("NewFF1() error = %v", err)
+			}
+
+			encrypted, err := cipher.Encrypt(numerals(t, tt.plaintext, tt.radix))
+			if err != nil {
+				t.Fatalf("Encrypt() error = %v", err)
+			}
+			if got := numeralString(encrypted); got != tt.ciphertext {
+				t.Errorf("Encrypt(%s) = %s, want %s", tt.plaintext, got, tt.ciphertext)
+			}
+
+			decrypted, err := cipher.Decrypt(encrypted)
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if got := numeralString(decrypted); got != tt.plaintext {
+				t.Errorf("Decrypt(%s) = %s, want %s", tt.ciphertext, got, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestFF1ShufflerNISTVector(t *testing.T) {
+	key, _ := hex.DecodeString(nistKey128)
+
+	shuffler, err := NewFF1Shuffler(key, nil, 10, 10)
+	if err != nil {
+		t.Fatalf("NewFF1Shuffler() error = %v", err)
+	}
+
+	encoded, err := shuffler.Encode(123456789)
+	if err != nil || encoded != 2433477484 {
+		t.Errorf("Encode(123456789) = %d, %v, want 2433477484", encoded, err)
+	}
+	decoded, err := shuffler.Decode(encoded)
+	if err != nil || decoded != 123456789 {
+		t.Errorf("Decode(%d) = %d, %v, want 123456789", encoded, decoded, err)
+	}
+	if _, err := shuffler.Encode(shuffler.MaxValue() + 1); err == nil {
+		t.Error("Encode() should reject values outside the domain")
+	}
+}
+
+func TestFF1ShufflerForEncoder(t *testing.T) {
+	config, _, err := Preset("proquint")
+	if err != nil {
+		t.Fatalf("Preset() error = %v", err)
+	}
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	key, _ := hex.DecodeString(nistKey128)
+	shuffler, err := NewFF1ShufflerForEncoder(key, []byte("phonid"), encoder)
+	if err != nil {
+		t.Fatalf("NewFF1ShufflerForEncoder() error = %v", err)
+	}
+	if shuffler.Radix() != 2 || shuffler.Length() != 32 || shuffler.MaxValue() != 0xFFFFFFFF {
+		t.Errorf("domain = %d^%d (max %d), want 2^32", shuffler.Radix(), shuffler.Length(), shuffler.MaxValue())
+	}
+
+	// Every shuffled value must map to a word of the largest pattern
+	for _, input := range []uint64{0, 1, 1337, 0xFFFFFFFF} {
+		encoded, err := shuffler.Encode(input)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", input, err)
+		}
+		word, err := encoder.Encode(PositiveInt(encoded))
+		if err != nil {
+			t.Fatalf("encoder.Encode(%d) error = %v", encoded, err)
+		}
+		number, err := encoder.Decode(word)
+		if err != nil {
+			t.Fatalf("encoder.Decode(%q) error = %v", word, err)
+		}
+		decoded, err := shuffler.Decode(uint64(number))
+		if err != nil || decoded != input {
+			t.Errorf("round trip of %d via %q = %d, %v", input, word, decoded, err)
+		}
+	}
+}
+
+func TestFF1ShufflerForEncoder_DefaultPatterns(t *testing.T) {
+	encoder, err := NewPhoneticEncoder(&PhonidConfig{})
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	key, _ := hex.DecodeString(nistKey128)
+	shuffler, err := NewFF1ShufflerForEncoder(key, nil, encoder)
+	if err != nil {
+		t.Fatalf("NewFF1ShufflerForEncoder() error = %v", err)
+	}
+	// CVCVCVCVCVC with 16 consonants and 5 vowels
+	if want := uint64(16*16*16*16*16*16*5*5*5*5*5) - 1; shuffler.MaxValue() != want {
+		t.Errorf("MaxValue() = %d, want %d", shuffler.MaxValue(), want)
+	}
+
+	for _, input := range []uint64{0, 1, 1337, shuffler.MaxValue()} {
+		encoded, err := shuffler.Encode(input)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", input, err)
+		}
+		if encoded > shuffler.MaxValue() {
+			t.Fatalf("Encode(%d) = %d is outside the capacity", input, encoded)
+		}
+		if _, err := encoder.Encode(PositiveInt(encoded)); err != nil {
+			t.Fatalf("encoder.Encode(%d) error = %v", encoded, err)
+		}
+		if decoded, err := shuffler.Decode(encoded); err != nil || decoded != input {
+			t.Errorf("Decode(%d) = %d, %v, want %d", encoded, decoded, err, input)
+		}
+	}
+}
+
+func TestFF1ShufflerCompleteBijection(t *testing.T) {
+	t.Run("perfect power", func(t *testing.T) {
+		shuffler, err := NewFF1ShufflerForCapacity([]byte("0123456789abcdef"), nil, 1_000_000)
+		if err != nil {
+			t.Fatalf("NewFF1ShufflerForCapacity() error = %v", err)
+		}
+		if shuffler.Radix() != 10 || shuffler.Length() != 6 {
+			t.Errorf("domain = %d^%d, want 10^6", shuffler.Radix(), shuffler.Length())
+		}
+		assertFF1Bijection(t, shuffler)
+	})
+
+	t.Run("cycle walking", func(t *testing.T) {
+		shuffler, err := NewFF1ShufflerForCapacity([]byte("0123456789abcdef"), nil, 1_000_003)
+		if err != nil {
+			t.Fatalf("NewFF1ShufflerForCapacity() error = %v", err)
+		}
+		if shuffler.MaxValue() != 1_000_002 {
+			t.Errorf("MaxValue() = %d, want 1000002", shuffler.MaxValue())
+		}
+		assertFF1Bijection(t, shuffler)
+	})
+}
+
+// assertFF1Bijection checks a sample of the shuffler's range for duplicates and round trips.
+func assertFF1Bijection(t *testing.T, shuffler *FF1Shuffler) {
+	t.Helper()
+	seen := make([]bool, shuffler.MaxValue()+1)
+	for i := uint64(0); i <= shuffler.MaxValue(); i += 97 {
+		encoded, err := shuffler.Encode(i)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", i, err)
+		}
+		if seen[encoded] {
+			t.Fatalf("Encode(%d) = %d is a duplicate", i, encoded)
+		}
+		seen[encoded] = true
+
+		if decoded, _ := shuffler.Decode(encoded); decoded != i {
+			t.Fatalf("Decode(%d) = %d, want %d", encoded, decoded, i)
+		}
+	}
+}
+
+func TestFF1InvalidInputs(t *testing.T) {
+	key := []byte("0123456789abcdef")
+
+	if _, err := NewFF1(key[:15], nil, 10); err == nil {
+		t.Error("NewFF1() should reject a 15-byte key")
+	}
+	if _, err := NewFF1(key, nil, 1); err == nil {
+		t.Error("NewFF1() should reject radix 1")
+	}
+	if _, err := NewFF1Shuffler(key, nil, 10, 5); err == nil {
+		t.Error("NewFF1Shuffler() should reject domains below one million")
+	}
+	if _, err := NewFF1Shuffler(key, nil, 10, 20); err == nil {
+		t.Error("NewFF1Shuffler() should reject domains beyond 64 bits")
+	}
+	if _, err := NewFF1ShufflerForCapacity(key, nil, FF1MinDomain-1); err == nil {
+		t.Error("NewFF1ShufflerForCapacity() should reject capacities below the minimum domain")
+	}
+
+	cipher, _ := NewFF1(key, nil, 10)
+	if _, err := cipher.Encrypt([]uint16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}); err == nil {
+		t.Error("Encrypt() should reject numerals >= radix")
+	}
+}
@@ -0,0 +1,97 @@
+package phonid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"maps"
+	"slices"
+)
+
+const (
+	fingerprintDomain = "phonid/fingerprint"
+	fingerprintBytes  = 16
+)
+
+// Fingerprint returns a canonical hash of everything that determines the
+// encoding: pattern order, the symbols of every position (after permutation),
+// phonotactic rules, shuffle algorithm, rounds, bit width, the round keys
+// derived from the seed and the pinned encoding version.
+// Two configs with the same fingerprint produce the same IDs. Unlike
+// ExpectedBitWidth, it changes when an alphabet is reordered or a seed changes.
+func (c *Config) Fingerprint() (string, error) {
+	encoder, err := c.validate()
+	if err != nil {
+		return "", err
+	}
+	return c.fingerprint(encoder)
+}
+
+// fingerprint hashes the validated config and its encoder.
+func (c *Config) fingerprint(encoder *PhoneticEncoder) (string, error) {
+	shuffler, err := NewFeistelShufflerWithOptions(*c.Shuffle)
+	if err != nil {
+		return "", fmt.Errorf("failed to create shuffler: %w", err)
+	}
+
+	h := sha256.New()
+	writeFingerprintString(h, fingerprintDomain)
+	writeFingerprintUint(h, c.EncodingVersion)
+
+	writeFingerprintUint(h, len(encoder.patternEncoders))
+	for _, pattern := range encoder.patternEncoders {
+		writeFingerprintString(h, pattern.pattern)
+		writeFingerprintUint(h, len(pattern.positions))
+		for _, position := range pattern.positions {
+			writeFingerprintStrings(h, position.symbols)
+		}
+	}
+
+	rules := c.Phonetic.Phonotactics
+	writeFingerprintStrings(h, slices.Sorted(slices.Values(rules.ForbiddenPairs)))
+	writeFingerprintUint(h, len(rules.AllowedClusters))
+	for _, key := range slices.Sorted(maps.Keys(rules.AllowedClusters)) {
+		writeFingerprintString(h, key)
+		writeFingerprintStrings(h, slices.Sorted(slices.Values(rules.AllowedClusters[key])))
+	}
+
+	writeFingerprintString(h, string(shuffler.RoundFunction()))
+	writeFingerprintUint(h, shuffler.BitWidth())
+	writeFingerprintUint(h, shuffler.Rounds())
+	if shuffler.secret != nil {
+		// Commit to the key without revealing it
+		mac := hmac.New(sha256.New, shuffler.secret)
+		mac.Write([]byte(fingerprintDomain))
+		h.Write(mac.Sum(nil))
+	} else {
+		for _, key := range shuffler.roundKeys {
+			_ = binary.Write(h, binary.BigEndian, key)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:fingerprintBytes]), nil
+}
+
+// writeFingerprintUint writes a length or count as a fixed-width integer.
+func writeFingerprintUint(h hash.Hash, value int) {
+	// #nosec G115 -- counts and lengths are non-negative
+	_ = binary.Write(h, binary.BigEndian, uint64(value))
+}
+
+// writeFingerprintString writes a length-prefixed string, so adjacent
+// strings cannot be re-split into the same byte stream.
+func writeFingerprintString(h hash.Hash, value string) {
+	writeFingerprintUint(h, len(value))
+	h.Write([]byte(value))
+}
+
+// writeFingerprintStrings writes a counted list of strings.
+func writeFingerprintStrings(h hash.Hash, values []string) {
+	writeFingerprintUint(h, len(values))
+	for _, value := range values {
+		writeFingerprintString(h, value)
+	}
+}
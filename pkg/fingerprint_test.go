@@ -0,0 +1,160 @@
+package phonid_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func fingerprintConfig(t *testing.T, consonants string, opts ...ConfigOption) *Config {
+	t.Helper()
+	phonetic := &PhonidConfig{
+		Patterns: []string{"CVC", "CVCVC"},
+		Placeholders: PlaceholderMap{
+			Consonant: RuneSet(consonants),
+			Vowel:     RuneSet("aeiou"),
+		},
+	}
+	config, err := NewConfigWithOptions(append([]ConfigOption{WithPhonetic(phonetic)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func mustFingerprint(t *testing.T, config *Config) string {
+	t.Helper()
+	fingerprint, err := config.Fingerprint()
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	return fingerprint
+}
+
+func TestConfig_Fingerprint_Pinned(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ConfigOption
+		want string
+	}{
+		{"defaults", nil, "c32f12d722b7c96427cce592e235fdd9"},
+		{"shuffled", []ConfigOption{WithRounds(4), WithSeed(12345)}, "07d7993ad7b203fad44f3cf7e0e51322"},
+		{"version 2", []ConfigOption{WithEncodingVersion(2)}, "42a9e6a170667cca46f37f9717e403b2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewConfigWithOptions(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := mustFingerprint(t, config); got != tt.want {
+				t.Errorf("Fingerprint() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Fingerprint_Changes(t *testing.T) {
+	base := mustFingerprint(t, fingerprintConfig(t, "bdkst", WithRounds(4), WithSeed(1)))
+
+	changed := map[string]*Config{
+		"reordered alphabet": fingerprintConfig(t, "dbkst", WithRounds(4), WithSeed(1)),
+		"seed":               fingerprintConfig(t, "bdkst", WithRounds(4), WithSeed(2)),
+		"rounds":             fingerprintConfig(t, "bdkst", WithRounds(5), WithSeed(1)),
+		"keyed": fingerprintConfig(t, "bdkst",
+			WithRounds(4),
+			WithRoundFunction(RoundFunctionHMACSHA256),
+			WithKey([]byte("0123456789abcdef")),
+		),
+	}
+	for name, config := range changed {
+		if mustFingerprint(t, config) == base {
+			t.Errorf("%s: fingerprint did not change", name)
+		}
+	}
+
+	// Bit width is unchanged here, so ExpectedBitWidth alone misses the change
+	if changed["reordered alphabet"].Shuffle.BitWidth != fingerprintConfig(t, "bdkst").Shuffle.BitWidth {
+		t.Error("reordering an alphabet should keep the bit width")
+	}
+}
+
+func TestConfig_Fingerprint_Stable(t *testing.T) {
+	// Without rounds the seed has no effect on IDs, and neither does pattern order
+	unshuffled := mustFingerprint(t, fingerprintConfig(t, "bdkst", WithSeed(1)))
+	if got := mustFingerprint(t, fingerprintConfig(t, "bdkst", WithSeed(2))); got != unshuffled {
+		t.Errorf("seed without rounds changed the fingerprint: %s != %s", got, unshuffled)
+	}
+
+	reordered, err := NewConfigWithOptions(WithPhonetic(&PhonidConfig{
+		Patterns: []string{"CVCVC", "CVC"},
+		Placeholders: PlaceholderMap{
+			Consonant: RuneSet("bdkst"),
+			Vowel:     RuneSet("aeiou"),
+		},
+	}), WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustFingerprint(t, reordered); got != unshuffled {
+		t.Errorf("pattern order changed the fingerprint: %s != %s", got, unshuffled)
+	}
+}
+
+func TestConfig_Validate_ExpectedFingerprint(t *testing.T) {
+	fingerprint := mustFingerprint(t, fingerprintConfig(t, "bdkst"))
+
+	if _, err := NewConfigWithOptions(
+		WithPhonetic(&PhonidConfig{
+			Patterns:     []string{"CVC", "CVCVC"},
+			Placeholders: PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")},
+		}),
+		WithExpectedFingerprint(fingerprint),
+	); err != nil {
+		t.Errorf("matching fingerprint: unexpected error %v", err)
+	}
+
+	_, err := NewConfigWithOptions(
+		WithPhonetic(&PhonidConfig{
+			Patterns:     []string{"CVC", "CVCVC"},
+			Placeholders: PlaceholderMap{Consonant: RuneSet("dbkst"), Vowel: RuneSet("aeiou")},
+		}),
+		WithExpectedFingerprint(fingerprint),
+	)
+	if err == nil || !strings.Contains(err.Error(), "preflight assertion failed") {
+		t.Errorf("reordered alphabet: error = %v, want preflight assertion", err)
+	}
+}
+
+func TestParseConfigRC_ExpectedFingerprint(t *testing.T) {
+	content := `
+expected_fingerprint = "c32f12d722b7c96427cce592e235fdd9"
+
+[[preflight]]
+input = 0
+output = "babab"
+
+[profiles.shuffled.shuffle]
+rounds = 4
+seed = 12345
+
+[[profiles.shuffled.preflight]]
+input = 0
+output = "babab"
+`
+	if _, _, err := ParseConfigRC(content); err != nil {
+		t.Errorf("ParseConfigRC() error = %v", err)
+	}
+
+	// Profiles do not inherit the top-level fingerprint
+	if _, _, err := ParseProfile(content, "shuffled"); err != nil {
+		t.Errorf("ParseProfile() error = %v", err)
+	}
+
+	broken := strings.Replace(content, "c32f", "0000", 1)
+	if _, _, err := ParseConfigRC(broken); err == nil {
+		t.Error("ParseConfigRC() expected fingerprint mismatch")
+	}
+}
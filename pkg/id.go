@@ -0,0 +1,151 @@
+package phonid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type (
+	// IDKind supplies the codec for one kind of ID. Implementations are
+	// usually empty structs, so each ID kind is a distinct type:
+	//
+	//	type orderKind struct{}
+	//
+	//	func (orderKind) Codec() phonid.WordCodec { return orderCodec }
+	//
+	//	type OrderID = phonid.ID[orderKind]
+	IDKind interface {
+		Codec() WordCodec
+	}
+
+	// ID carries a number that is phonetic on the wire and numeric in the
+	// database: text and JSON encodings use the kind's codec, while
+	// database/sql stores the plain integer.
+	ID[K IDKind] struct {
+		number PositiveInt
+	}
+)
+
+var errNoCodec = errors.New("ID kind has no codec")
+
+// NewID wraps a number as an ID of kind K.
+func NewID[K IDKind](number PositiveInt) ID[K] {
+	return ID[K]{number: number}
+}
+
+// ParseID decodes a word into an ID of kind K.
+func ParseID[K IDKind](word string) (ID[K], error) {
+	var id ID[K]
+	err := id.UnmarshalText([]byte(word))
+	return id, err
+}
+
+// Number returns the numeric value.
+func (id ID[K]) Number() PositiveInt {
+	return id.number
+}
+
+// String returns the phonetic word, or a marker containing the number if it
+// cannot be encoded.
+func (id ID[K]) String() string {
+	word, err := id.MarshalText()
+	if err != nil {
+		return fmt.Sprintf("%%!phonid(%d)", id.number)
+	}
+	return string(word)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID[K]) MarshalText() ([]byte, error) {
+	codec := codecFor[K]()
+	if codec == nil {
+		return nil, errNoCodec
+	}
+
+	word, err := codec.Encode(id.number)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(word), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID[K]) UnmarshalText(text []byte) error {
+	codec := codecFor[K]()
+	if codec == nil {
+		return errNoCodec
+	}
+
+	number, err := codec.Decode(string(text))
+	if err != nil {
+		return err
+	}
+	id.number = PositiveInt(number)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler; IDs are JSON strings.
+func (id ID[K]) MarshalJSON() ([]byte, error) {
+	word, err := id.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(word))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Only strings are accepted, so
+// numeric IDs never leak into the API; null leaves the ID unchanged.
+func (id *ID[K]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var word string
+	if err := json.Unmarshal(data, &word); err != nil {
+		return fmt.Errorf("ID must be a JSON string: %w", err)
+	}
+	return id.UnmarshalText([]byte(word))
+}
+
+// Value implements driver.Valuer; IDs are stored as integers.
+func (id ID[K]) Value() (driver.Value, error) {
+	if err := id.number.Validate(); err != nil {
+		return nil, err
+	}
+	return int64(id.number), nil
+}
+
+// Scan implements sql.Scanner for integer columns. Use sql.Null[ID[K]] for
+// nullable columns.
+func (id *ID[K]) Scan(src any) error {
+	var number int64
+	switch v := src.(type) {
+	case int64:
+		number = v
+	case []byte:
+		return id.Scan(string(v))
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into ID: %w", v, err)
+		}
+		number = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into ID", src)
+	}
+
+	if number < 0 {
+		return fmt.Errorf("cannot scan negative value %d into ID", number)
+	}
+	id.number = PositiveInt(number)
+	return nil
+}
+
+// codecFor returns the codec of kind K.
+func codecFor[K IDKind]() WordCodec {
+	var kind K
+	return kind.Codec()
+}
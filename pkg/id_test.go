@@ -0,0 +1,147 @@
+package phonid_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+type (
+	orderKind    struct{}
+	missingKind  struct{}
+	nilCodecKind struct{}
+
+	OrderID = ID[orderKind]
+)
+
+var orderCodec = sync.OnceValue(func() *PhoneticEncoder {
+	config, _, err := Preset("proquint")
+	if err != nil {
+		panic(err)
+	}
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		panic(err)
+	}
+	return encoder
+})
+
+func (orderKind) Codec() WordCodec    { return orderCodec() }
+func (missingKind) Codec() WordCodec  { return nil }
+func (nilCodecKind) Codec() WordCodec { return (*Codec)(nil) }
+
+var (
+	_ encoding.TextMarshaler   = OrderID{}
+	_ encoding.TextUnmarshaler = (*OrderID)(nil)
+	_ json.Marshaler           = OrderID{}
+	_ json.Unmarshaler         = (*OrderID)(nil)
+	_ sql.Scanner              = (*OrderID)(nil)
+	_ driver.Valuer            = OrderID{}
+	_ fmt.Stringer             = OrderID{}
+)
+
+func TestID_Text(t *testing.T) {
+	id := NewID[orderKind](1337)
+	if id.String() != "babab-bihun" {
+		t.Errorf("String() = %q, want %q", id.String(), "babab-bihun")
+	}
+
+	parsed, err := ParseID[orderKind]("babab-bihun")
+	if err != nil || parsed != id {
+		t.Errorf("ParseID() = %v, %v, want %v", parsed.Number(), err, id.Number())
+	}
+
+	if _, err := ParseID[orderKind]("not-a-word"); err == nil {
+		t.Error("ParseID() should reject invalid words")
+	}
+	if _, err := ParseID[missingKind]("babab"); err == nil {
+		t.Error("ParseID() should fail without a codec")
+	}
+}
+
+func TestID_NilCodec(t *testing.T) {
+	id := NewID[nilCodecKind](42)
+	if _, err := id.MarshalText(); err == nil {
+		t.Error("MarshalText() should fail with a nil *Codec")
+	}
+	if _, err := json.Marshal(id); err == nil {
+		t.Error("MarshalJSON() should fail with a nil *Codec")
+	}
+	if got := id.String(); got != "%!phonid(42)" {
+		t.Errorf("String() = %q, want %q", got, "%!phonid(42)")
+	}
+	if _, err := ParseID[nilCodecKind]("babab"); err == nil {
+		t.Error("ParseID() should fail with a nil *Codec")
+	}
+}
+
+func TestID_JSON(t *testing.T) {
+	type order struct {
+		ID     OrderID  `json:"id"`
+		Parent *OrderID `json:"parent"`
+	}
+
+	data, err := json.Marshal(order{ID: NewID[orderKind](1337)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"id":"babab-bihun","parent":null}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var decoded order
+	if err := json.Unmarshal([]byte(`{"id":"babab-bihun","parent":"babab-babab"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.ID.Number() != 1337 || decoded.Parent == nil || decoded.Parent.Number() != 0 {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":1337}`), &decoded); err == nil {
+		t.Error("Unmarshal() should reject numeric IDs")
+	}
+}
+
+func TestID_SQL(t *testing.T) {
+	value, err := NewID[orderKind](1337).Value()
+	if err != nil || value != int64(1337) {
+		t.Errorf("Value() = %v, %v, want 1337", value, err)
+	}
+
+	tests := []struct {
+		src     any
+		want    PositiveInt
+		wantErr bool
+	}{
+		{int64(1337), 1337, false},
+		{[]byte("42"), 42, false},
+		{"7", 7, false},
+		{int64(-1), 0, true},
+		{"babab", 0, true},
+		{nil, 0, true},
+		{3.5, 0, true},
+	}
+
+	for _, tt := range tests {
+		var id OrderID
+		err := id.Scan(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Scan(%v) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			continue
+		}
+		if id.Number() != tt.want {
+			t.Errorf("Scan(%v) = %d, want %d", tt.src, id.Number(), tt.want)
+		}
+	}
+
+	var nullable sql.Null[OrderID]
+	if err := nullable.Scan(nil); err != nil || nullable.Valid {
+		t.Errorf("sql.Null Scan(nil) = %v, valid=%v", err, nullable.Valid)
+	}
+}
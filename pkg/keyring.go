@@ -0,0 +1,161 @@
+package phonid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// VersionMarker is the dedicated placeholder whose symbols identify key versions.
+// It is reserved for key rings and cannot appear in patterns.
+const VersionMarker PlaceholderType = 'K'
+
+type (
+	// KeyVersion is one generation of shuffle parameters in a key ring.
+	KeyVersion struct {
+		Marker string // Leading syllable identifying this version in encoded words
+		Seed   uint64
+		Rounds int
+	}
+
+	// KeyRingConfig describes a key ring. Versions are ordered oldest first;
+	// the last version issues new IDs.
+	KeyRingConfig struct {
+		Phonetic *PhonidConfig
+		Versions []KeyVersion
+	}
+
+	// KeyRing encodes numbers under its newest key version and decodes words
+	// issued under any version, so a leaked seed can be retired without
+	// invalidating published IDs.
+	KeyRing struct {
+		encoder  *PhoneticEncoder
+		versions []keyRingVersion
+		maxValue uint64
+	}
+
+	// keyRingVersion pairs a marker with its shuffler.
+	keyRingVersion struct {
+		marker   string
+		shuffler *FeistelShuffler
+	}
+)
+
+// Validate checks that markers are unique and prefix-free.
+func (kc *KeyRingConfig) Validate() error {
+	if kc.Phonetic == nil {
+		return errors.New("phonetic config is required")
+	}
+	if len(kc.Versions) == 0 {
+		return errors.New("at least one key version is required")
+	}
+
+	markers := make([]string, len(kc.Versions))
+	for i, version := range kc.Versions {
+		markers[i] = version.Marker
+	}
+	if hasDuplicates(markers) {
+		return fmt.Errorf("placeholder '%c' contains duplicate markers", VersionMarker)
+	}
+	// Markers always lead the word, so prefix-freedom alone makes them unambiguous
+	return validatePrefixFree(VersionMarker, markers)
+}
+
+// NewKeyRing creates a key ring from a validated config.
+func NewKeyRing(config *KeyRingConfig) (*KeyRing, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid key ring config: %w", err)
+	}
+
+	encoder, err := NewPhoneticEncoder(config.Phonetic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	largestPattern := encoder.patternEncoders[len(encoder.patternEncoders)-1]
+	// The Feistel network needs at least 4 bits and, under the default encoding
+	// version, an even width to permute every value; cycle walking covers the rest
+	bitWidth := max(calculateRequiredBitWidth(int(largestPattern.totalCombinations)), MinBitWidth)
+	bitWidth += bitWidth % 2
+
+	kr := &KeyRing{
+		encoder: encoder,
+		// #nosec G115 -- totalCombinations is positive
+		maxValue: uint64(largestPattern.totalCombinations) - 1,
+	}
+	for _, version := range config.Versions {
+		shuffler, err := NewFeistelShuffler(bitWidth, version.Rounds, version.Seed)
+		if err != nil {
+			return nil, fmt.Errorf("key version %q: %w", version.Marker, err)
+		}
+		kr.versions = append(kr.versions, keyRingVersion{marker: version.Marker, shuffler: shuffler})
+	}
+
+	return kr, nil
+}
+
+// Encode shuffles number with the newest key version and prefixes its marker.
+func (kr *KeyRing) Encode(number PositiveInt) (string, error) {
+	if err := number.Validate(); err != nil {
+		return "", err
+	}
+	// #nosec G115 -- number is validated non-negative
+	if uint64(number) > kr.maxValue {
+		return "", fmt.Errorf("number %d exceeds key ring capacity (max: %d)", number, kr.maxValue)
+	}
+
+	version := kr.versions[len(kr.versions)-1]
+	// #nosec G115 -- number is validated non-negative
+	shuffled, err := cycleWalk(version.shuffler.Encode, uint64(number), kr.maxValue)
+	if err != nil {
+		return "", err
+	}
+
+	// #nosec G115 -- shuffled is at most maxValue, which fits in PositiveInt
+	word, err := kr.encoder.Encode(PositiveInt(shuffled))
+	if err != nil {
+		return "", err
+	}
+	return version.marker + word, nil
+}
+
+// Decode selects the key version from the word's marker and reverses Encode.
+func (kr *KeyRing) Decode(word string) (int, error) {
+	number, _, err := kr.DecodeVersion(word)
+	return number, err
+}
+
+// DecodeVersion is like Decode but also reports the index of the key version
+// the word was issued under, so callers can re-issue IDs on old keys.
+func (kr *KeyRing) DecodeVersion(word string) (int, int, error) {
+	for index, version := range kr.versions {
+		rest, ok := strings.CutPrefix(word, version.marker)
+		if !ok {
+			continue
+		}
+
+		shuffled, err := kr.encoder.Decode(rest)
+		if err != nil {
+			return 0, 0, err
+		}
+		// #nosec G115 -- Decode returns non-negative numbers
+		if uint64(shuffled) > kr.maxValue {
+			return 0, 0, fmt.Errorf("word %q is outside the key ring capacity", word)
+		}
+
+		// #nosec G115 -- Decode returns non-negative numbers
+		number, err := cycleWalk(version.shuffler.Decode, uint64(shuffled), kr.maxValue)
+		if err != nil {
+			return 0, 0, err
+		}
+		// #nosec G115 -- number is at most maxValue, which fits in int
+		return int(number), index, nil
+	}
+
+	return 0, 0, fmt.Errorf("word %q has no known key version marker", word)
+}
+
+// Versions returns the number of key versions.
+func (kr *KeyRing) Versions() int {
+	return len(kr.versions)
+}
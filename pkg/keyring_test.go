@@ -0,0 +1,139 @@
+package phonid_test
+
+import (
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func keyRingConfig(versions ...KeyVersion) *KeyRingConfig {
+	return &KeyRingConfig{
+		Phonetic: &PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdkst"),
+				Vowel:     RuneSet("aeiou"),
+			},
+		},
+		Versions: versions,
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	old, err := NewKeyRing(keyRingConfig(KeyVersion{Marker: "ka", Seed: 1, Rounds: 4}))
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+	rotated, err := NewKeyRing(keyRingConfig(
+		KeyVersion{Marker: "ka", Seed: 1, Rounds: 4},
+		KeyVersion{Marker: "ko", Seed: 2, Rounds: 4},
+	))
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+
+	published, err := old.Encode(1337)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// IDs issued before the rotation still decode
+	number, version, err := rotated.DecodeVersion(published)
+	if err != nil || number != 1337 || version != 0 {
+		t.Errorf("DecodeVersion(%q) = %d, %d, %v, want 1337, 0", published, number, version, err)
+	}
+
+	// New IDs are issued under the newest key
+	fresh, err := rotated.Encode(1337)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if fresh != "kobudab" {
+		t.Errorf("Cross-platform inconsistency: Encode(1337) = %q, want \"kobudab\"", fresh)
+	}
+	number, version, err = rotated.DecodeVersion(fresh)
+	if err != nil || number != 1337 || version != 1 {
+		t.Errorf("DecodeVersion(%q) = %d, %d, %v, want 1337, 1", fresh, number, version, err)
+	}
+}
+
+func TestKeyRing_CompleteBijection(t *testing.T) {
+	ring, err := NewKeyRing(keyRingConfig(KeyVersion{Marker: "z", Seed: 42, Rounds: 6}))
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+
+	// 5*5*5*5*5 = 3125 values, shuffled over 12 bits with cycle walking
+	seen := make(map[string]bool)
+	for n := range PositiveInt(3125) {
+		word, err := ring.Encode(n)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", n, err)
+		}
+		if seen[word] {
+			t.Fatalf("Encode(%d) = %q is a duplicate", n, word)
+		}
+		seen[word] = true
+
+		decoded, err := ring.Decode(word)
+		if err != nil || decoded != int(n) {
+			t.Fatalf("Decode(%q) = %d, %v, want %d", word, decoded, err, n)
+		}
+	}
+
+	if _, err := ring.Encode(3125); err == nil {
+		t.Error("Encode() should reject numbers beyond the capacity")
+	}
+}
+
+func TestKeyRing_OddBitWidth(t *testing.T) {
+	config := keyRingConfig(KeyVersion{Marker: "z", Seed: 42, Rounds: 4})
+	config.Phonetic.Patterns = []string{"CVC"}
+	ring, err := NewKeyRing(config)
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+
+	// 125 values need 7 bits, an odd width the version 1 split cannot permute
+	seen := make(map[string]bool)
+	for n := range PositiveInt(125) {
+		word, err := ring.Encode(n)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", n, err)
+		}
+		if seen[word] {
+			t.Fatalf("Encode(%d) = %q is a duplicate", n, word)
+		}
+		seen[word] = true
+
+		if decoded, err := ring.Decode(word); err != nil || decoded != int(n) {
+			t.Fatalf("Decode(%q) = %d, %v, want %d", word, decoded, err, n)
+		}
+	}
+}
+
+func TestKeyRing_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []KeyVersion
+	}{
+		{"no versions", nil},
+		{"empty marker", []KeyVersion{{Marker: "", Rounds: 4}}},
+		{"duplicate marker", []KeyVersion{{Marker: "ka", Rounds: 4}, {Marker: "ka", Seed: 1, Rounds: 4}}},
+		{"prefix marker", []KeyVersion{{Marker: "k", Rounds: 4}, {Marker: "ka", Seed: 1, Rounds: 4}}},
+		{"invalid rounds", []KeyVersion{{Marker: "ka", Rounds: 99}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyRing(keyRingConfig(tt.versions...)); err == nil {
+				t.Error("NewKeyRing() should fail")
+			}
+		})
+	}
+
+	ring, _ := NewKeyRing(keyRingConfig(KeyVersion{Marker: "ka", Rounds: 4}))
+	if _, err := ring.Decode("kubab"); err == nil {
+		t.Error("Decode() should reject unknown markers")
+	}
+}
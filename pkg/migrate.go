@@ -0,0 +1,144 @@
+package phonid
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type (
+	// Migration re-encodes words issued under one codec with another, for
+	// moving stored IDs when CheckCompatibility reports a breaking change.
+	Migration struct {
+		from WordCodec
+		to   WordCodec
+	}
+
+	// MultiDecoder accepts words issued under any of several codecs, so legacy
+	// words keep working during a migration. It encodes with the first codec.
+	MultiDecoder struct {
+		codecs []WordCodec
+	}
+)
+
+// NewMigration creates a migration from one codec to another.
+func NewMigration(from, to WordCodec) (*Migration, error) {
+	if from == nil || to == nil {
+		return nil, errors.New("migration needs both a source and a target codec")
+	}
+	return &Migration{from: from, to: to}, nil
+}
+
+// Migrate decodes word with the source codec and encodes the number with the target.
+func (m *Migration) Migrate(word string) (string, error) {
+	number, err := m.from.Decode(word)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %q: %w", word, err)
+	}
+	migrated, err := m.to.Encode(PositiveInt(number))
+	if err != nil {
+		return "", fmt.Errorf("failed to re-encode %q (%d): %w", word, number, err)
+	}
+	return migrated, nil
+}
+
+// MigrateStream reads one word per line from r and writes the migrated word
+// for each line to w. Surrounding whitespace is ignored and blank lines are
+// kept, so output lines stay aligned with input lines. If mapping is not nil,
+// a CSV file with an "old,new" header and one row per word is written to it.
+// It returns the number of migrated words and stops at the first failure.
+func (m *Migration) MigrateStream(r io.Reader, w io.Writer, mapping io.Writer) (migrated int, err error) {
+	// Words migrated before a failure are still written
+	out := bufio.NewWriter(w)
+	defer func() {
+		if flushErr := out.Flush(); err == nil {
+			err = flushErr
+		}
+	}()
+
+	var mappingWriter *csv.Writer
+	if mapping != nil {
+		mappingWriter = csv.NewWriter(mapping)
+		defer func() {
+			mappingWriter.Flush()
+			if flushErr := mappingWriter.Error(); err == nil && flushErr != nil {
+				err = fmt.Errorf("failed to write mapping: %w", flushErr)
+			}
+		}()
+		if err := mappingWriter.Write([]string{"old", "new"}); err != nil {
+			return 0, fmt.Errorf("failed to write mapping: %w", err)
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			if _, err := out.WriteString("\n"); err != nil {
+				return migrated, err
+			}
+			continue
+		}
+
+		result, err := m.Migrate(word)
+		if err != nil {
+			return migrated, fmt.Errorf("line %d: %w", line, err)
+		}
+		if _, err := out.WriteString(result + "\n"); err != nil {
+			return migrated, err
+		}
+		if mappingWriter != nil {
+			if err := mappingWriter.Write([]string{word, result}); err != nil {
+				return migrated, fmt.Errorf("failed to write mapping: %w", err)
+			}
+		}
+		migrated++
+	}
+	if err := scanner.Err(); err != nil {
+		return migrated, fmt.Errorf("failed to read words: %w", err)
+	}
+	return migrated, nil
+}
+
+// NewMultiDecoder creates a decoder trying codecs in order. List the current
+// codec first: a word that is valid under several codecs decodes with the
+// first one that accepts it, so new words never resolve to legacy numbers.
+func NewMultiDecoder(codecs ...WordCodec) (*MultiDecoder, error) {
+	if len(codecs) == 0 {
+		return nil, errors.New("at least one codec is required")
+	}
+	for i, codec := range codecs {
+		if codec == nil {
+			return nil, fmt.Errorf("codec %d is nil", i)
+		}
+	}
+	return &MultiDecoder{codecs: codecs}, nil
+}
+
+// Encode encodes number with the current (first) codec.
+func (md *MultiDecoder) Encode(number PositiveInt) (string, error) {
+	return md.codecs[0].Encode(number)
+}
+
+// Decode decodes word with the first codec that accepts it.
+func (md *MultiDecoder) Decode(word string) (int, error) {
+	number, _, err := md.DecodeIndex(word)
+	return number, err
+}
+
+// DecodeIndex is like Decode but also reports the index of the codec that
+// accepted the word; a non-zero index marks a legacy word to re-issue.
+func (md *MultiDecoder) DecodeIndex(word string) (int, int, error) {
+	errs := make([]error, 0, len(md.codecs))
+	for index, codec := range md.codecs {
+		number, err := codec.Decode(word)
+		if err == nil {
+			return number, index, nil
+		}
+		errs = append(errs, fmt.Errorf("codec %d: %w", index, err))
+	}
+	return 0, 0, fmt.Errorf("word %q is not valid under any codec: %w", word, errors.Join(errs...))
+}
@@ -0,0 +1,127 @@
+package phonid_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func migrationCodecs(t *testing.T) (*Codec, *Codec) {
+	t.Helper()
+	placeholders := PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}
+	oldCodec, err := NewCodec(compatConfig(t, []string{"CVC", "CVCVC"}, placeholders))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newCodec, err := NewCodec(compatConfig(t, []string{"CVC", "CVCVC"}, placeholders, WithRounds(4), WithSeed(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return oldCodec, newCodec
+}
+
+func TestMigration_Migrate(t *testing.T) {
+	oldCodec, newCodec := migrationCodecs(t)
+	migration, err := NewMigration(oldCodec, newCodec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, number := range []PositiveInt{0, 42, 124, 125, 3124} {
+		oldWord, _ := oldCodec.Encode(number)
+		want, _ := newCodec.Encode(number)
+
+		got, err := migration.Migrate(oldWord)
+		if err != nil {
+			t.Fatalf("Migrate(%q) error = %v", oldWord, err)
+		}
+		if got != want {
+			t.Errorf("Migrate(%q) = %q, want %q", oldWord, got, want)
+		}
+	}
+
+	if _, err := migration.Migrate("xyz"); err == nil {
+		t.Error("Migrate() expected error for invalid word")
+	}
+	if _, err := NewMigration(nil, newCodec); err == nil {
+		t.Error("NewMigration() expected error for nil codec")
+	}
+}
+
+func TestMigration_MigrateStream(t *testing.T) {
+	oldCodec, newCodec := migrationCodecs(t)
+	migration, err := NewMigration(oldCodec, newCodec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := oldCodec.Encode(1)
+	second, _ := oldCodec.Encode(1337)
+	wantFirst, _ := newCodec.Encode(1)
+	wantSecond, _ := newCodec.Encode(1337)
+
+	var out, mapping bytes.Buffer
+	count, err := migration.MigrateStream(strings.NewReader(first+"\n\n  "+second+"  \n"), &out, &mapping)
+	if err != nil {
+		t.Fatalf("MigrateStream() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("MigrateStream() migrated %d words, want 2", count)
+	}
+	if want := wantFirst + "\n\n" + wantSecond + "\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if want := "old,new\n" + first + "," + wantFirst + "\n" + second + "," + wantSecond + "\n"; mapping.String() != want {
+		t.Errorf("mapping = %q, want %q", mapping.String(), want)
+	}
+
+	_, err = migration.MigrateStream(strings.NewReader(first+"\nxyz\n"), &out, nil)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("MigrateStream() error = %v, want line 2 failure", err)
+	}
+}
+
+func TestMultiDecoder(t *testing.T) {
+	legacy, err := NewCodec(compatConfig(t, []string{"CVC", "CVCVC"},
+		PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := NewCodec(compatConfig(t, []string{"CVC", "CVCVC"},
+		PlaceholderMap{Consonant: RuneSet("fglmn"), Vowel: RuneSet("aeiou")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := NewMultiDecoder(current, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacyWord, _ := legacy.Encode(42)
+	number, index, err := decoder.DecodeIndex(legacyWord)
+	if err != nil || number != 42 || index != 1 {
+		t.Errorf("DecodeIndex(%q) = %d, %d, %v, want 42, 1, nil", legacyWord, number, index, err)
+	}
+
+	currentWord, err := decoder.Encode(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := current.Encode(42); currentWord != want {
+		t.Errorf("Encode(42) = %q, want %q from the current codec", currentWord, want)
+	}
+	number, index, err = decoder.DecodeIndex(currentWord)
+	if err != nil || number != 42 || index != 0 {
+		t.Errorf("DecodeIndex(%q) = %d, %d, %v, want 42, 0, nil", currentWord, number, index, err)
+	}
+
+	if _, err := decoder.Decode("xyz"); err == nil {
+		t.Error("Decode() expected error for a word no codec accepts")
+	}
+	if _, err := NewMultiDecoder(); err == nil {
+		t.Error("NewMultiDecoder() expected error without codecs")
+	}
+}
@@ -0,0 +1,59 @@
+package phonid
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+)
+
+// placeholderWide marks a permutation shared by all positions of a placeholder.
+const placeholderWide = -1
+
+type (
+	// PermutationConfig shuffles placeholder alphabets before encoding, so two
+	// projects with identical patterns but different seeds produce visibly
+	// different words. The numeric space itself is unchanged.
+	PermutationConfig struct {
+		Seed        uint64
+		PerPosition bool // Shuffle each position independently instead of once per placeholder
+	}
+)
+
+// permute reorders the symbols of every position according to the config.
+func (e *PatternEncoder) permute(config *PermutationConfig) {
+	if config == nil {
+		return
+	}
+
+	for i := range e.positions {
+		position := &e.positions[i]
+		index := placeholderWide
+		if config.PerPosition {
+			index = i
+		}
+		placeholder := PlaceholderType([]rune(position.placeholder)[0])
+		position.symbols = permuteSymbols(position.symbols, config.Seed, placeholder, index)
+	}
+}
+
+// permuteSymbols returns a seeded Fisher-Yates shuffle of symbols.
+// Swap indices are derived with FNV-1a, like the Feistel round keys, so results
+// are identical on every platform.
+func permuteSymbols(symbols []string, seed uint64, placeholder PlaceholderType, position int) []string {
+	permuted := slices.Clone(symbols)
+
+	h := fnv.New64a()
+	for i := len(permuted) - 1; i > 0; i-- {
+		h.Reset()
+		_ = binary.Write(h, binary.LittleEndian, seed)
+		_ = binary.Write(h, binary.LittleEndian, int32(placeholder))
+		_ = binary.Write(h, binary.LittleEndian, int64(position))
+		_ = binary.Write(h, binary.LittleEndian, int64(i))
+
+		// #nosec G115 -- i is a non-negative slice index
+		j := int(h.Sum64() % uint64(i+1))
+		permuted[i], permuted[j] = permuted[j], permuted[i]
+	}
+
+	return permuted
+}
@@ -0,0 +1,113 @@
+package phonid_test
+
+import (
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestPermutationCrossPlatformConsistency(t *testing.T) {
+	tests := []struct {
+		permutation *PermutationConfig
+		input       PositiveInt
+		want        string
+	}{
+		{nil, 1337, "babab-bihun"},
+		{&PermutationConfig{Seed: 12345}, 0, "vovov-vovov"},
+		{&PermutationConfig{Seed: 12345}, 42, "vovov-vovad"},
+		{&PermutationConfig{Seed: 12345}, 1337, "vovov-vuniz"},
+		{&PermutationConfig{Seed: 12345}, 0xFFFFFFFF, "mimim-mimim"},
+		{&PermutationConfig{Seed: 12346}, 1337, "hihih-hufol"},
+		{&PermutationConfig{Seed: 12345, PerPosition: true}, 0, "sodil-jidig"},
+		{&PermutationConfig{Seed: 12345, PerPosition: true}, 1337, "sodil-juhar"},
+		{&PermutationConfig{Seed: 12345, PerPosition: true}, 0xFFFFFFFF, "bafoh-komap"},
+	}
+
+	for _, tt := range tests {
+		config, _, err := Preset("proquint")
+		if err != nil {
+			t.Fatalf("Preset() error = %v", err)
+		}
+		config.Permutation = tt.permutation
+
+		encoder, err := NewPhoneticEncoder(config)
+		if err != nil {
+			t.Fatalf("NewPhoneticEncoder() error = %v", err)
+		}
+
+		got, err := encoder.Encode(tt.input)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Cross-platform inconsistency: permutation=%+v input=%d, expected=%q, got=%q",
+				tt.permutation, tt.input, tt.want, got)
+		}
+
+		decoded, err := encoder.Decode(got)
+		if err != nil || decoded != int(tt.input) {
+			t.Errorf("Decode(%q) = %d, %v, want %d", got, decoded, err, tt.input)
+		}
+	}
+}
+
+func TestPermutationCompleteBijection(t *testing.T) {
+	for _, perPosition := range []bool{false, true} {
+		config := &PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdkst"),
+				Vowel:     RuneSet("aeiou"),
+			},
+			Permutation: &PermutationConfig{Seed: 7, PerPosition: perPosition},
+		}
+
+		encoder, err := NewPhoneticEncoder(config)
+		if err != nil {
+			t.Fatalf("NewPhoneticEncoder() error = %v", err)
+		}
+
+		seen := make(map[string]bool)
+		for n := range encoder.Stats().MaxValue + 1 {
+			word, err := encoder.Encode(n)
+			if err != nil {
+				t.Fatalf("Encode(%d) error = %v", n, err)
+			}
+			if seen[word] {
+				t.Fatalf("perPosition=%v: Encode(%d) = %q is a duplicate", perPosition, n, word)
+			}
+			seen[word] = true
+
+			decoded, err := encoder.Decode(word)
+			if err != nil || decoded != int(n) {
+				t.Fatalf("perPosition=%v: Decode(%q) = %d, %v, want %d", perPosition, word, decoded, err, n)
+			}
+		}
+	}
+}
+
+func TestParsePhonidRCPermutation(t *testing.T) {
+	content := `
+[phonetic]
+preset = "proquint"
+
+[phonetic.permutation]
+seed = 12345
+
+[[preflight]]
+input = 1337
+output = "vovov-vuniz"
+`
+	config, preflight, err := ParsePhonidRC(content)
+	if err != nil {
+		t.Fatalf("ParsePhonidRC() error = %v", err)
+	}
+
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+	if err := encoder.ValidatePreflight(preflight); err != nil {
+		t.Errorf("ValidatePreflight() error = %v", err)
+	}
+}
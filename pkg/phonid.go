@@ -101,6 +101,15 @@ type (
 	PlaceholderType rune
 	PlaceholderMap  map[PlaceholderType]RuneSet
 
+	// SyllableMap maps placeholders to multi-character members such as digraphs
+	// ("sh", "th", "ng") or kana syllables ("ka", "shi"). Members of one set must
+	// be prefix-free so that words remain uniquely parseable.
+	SyllableMap map[PlaceholderType][]string
+
+	// symbolMap is the unified view of PlaceholderMap and SyllableMap, with
+	// every rune of a RuneSet as a single-rune symbol.
+	symbolMap map[PlaceholderType][]string
+
 	// RuneSet is a slice of runes that can be unmarshaled from a string.
 	// This allows TOML configs to use simple strings like C = "bcdfg" instead of arrays.
 	RuneSet []rune
@@ -118,8 +127,11 @@ type (
 	//	    },
 	//	}
 	PhonidConfig struct {
-		Patterns     []string       // e.g., "CVCVC", "CLVCV", "VCCVL" // Each character becomes a placeholder key
-		Placeholders PlaceholderMap // Maps placeholder to character set, e.g., {"C": "bcdfg", "V": "aeiou"}
+		Patterns     []string           // e.g., "CVCVC", "CLVCV", "VCCVL" // Each character becomes a placeholder key
+		Placeholders PlaceholderMap     // Maps placeholder to character set, e.g., {"C": "bcdfg", "V": "aeiou"}
+		Syllables    SyllableMap        // Optional: maps placeholder to syllables, e.g., {"C": {"sh", "th", "k"}}
+		Phonotactics PhonotacticRules   // Optional: restricts adjacent characters, e.g., no "xq"
+		Permutation  *PermutationConfig // Optional: seeded shuffle of alphabets; nil keeps config order
 	}
 )
 
@@ -135,10 +147,15 @@ func (pc *PhonidConfig) Validate() error {
 	if len(pc.Patterns) == 0 {
 		pc.Patterns = DefaultPatterns
 	}
-	if len(pc.Placeholders) == 0 {
+	if len(pc.Placeholders) == 0 && len(pc.Syllables) == 0 {
 		pc.Placeholders = DefaultPlaceholders
 	}
 
+	symbols, err := pc.symbols()
+	if err != nil {
+		return err
+	}
+
 	patterns := pc.Patterns
 	patternLengths := make(map[int]struct{})
 
@@ -157,16 +174,41 @@ func (pc *PhonidConfig) Validate() error {
 		}
 
 		// Validate individual pattern
-		if err := validatePattern(p, pc.Placeholders); err != nil {
+		if err := validatePattern(p, symbols); err != nil {
 			return fmt.Errorf("pattern '%s': %w", p, err)
 		}
 		patternLengths[patternLen] = struct{}{}
 	}
 
+	if err := pc.Phonotactics.validate(symbols); err != nil {
+		return fmt.Errorf("phonotactic rules: %w", err)
+	}
+
 	return nil
 }
 
-func validatePattern(pattern string, placeholders PlaceholderMap) error {
+// symbols merges Placeholders and Syllables into one symbolMap.
+func (pc *PhonidConfig) symbols() (symbolMap, error) {
+	symbols := make(symbolMap, len(pc.Placeholders)+len(pc.Syllables))
+	for placeholder, chars := range pc.Placeholders {
+		members := make([]string, len(chars))
+		for i, char := range chars {
+			members[i] = string(char)
+		}
+		symbols[placeholder] = members
+	}
+
+	for placeholder, syllables := range pc.Syllables {
+		if _, exists := symbols[placeholder]; exists {
+			return nil, fmt.Errorf("placeholder '%c' is defined as both characters and syllables", placeholder)
+		}
+		symbols[placeholder] = syllables
+	}
+
+	return symbols, nil
+}
+
+func validatePattern(pattern string, placeholders symbolMap) error {
 	placeholderCounts, err := countPlaceholders(pattern, placeholders)
 	if err != nil {
 		return err
@@ -188,7 +230,7 @@ func validatePattern(pattern string, placeholders PlaceholderMap) error {
 }
 
 // countPlaceholders counts occurrences of each placeholder in the pattern.
-func countPlaceholders(pattern string, placeholders PlaceholderMap) (map[PlaceholderType]int, error) {
+func countPlaceholders(pattern string, placeholders symbolMap) (map[PlaceholderType]int, error) {
 	counts := make(map[PlaceholderType]int)
 
 	for _, r := range pattern {
@@ -203,17 +245,21 @@ func countPlaceholders(pattern string, placeholders PlaceholderMap) (map[Placeho
 }
 
 // validatePlaceholderSets validates each placeholder's character set.
-func validatePlaceholderSets(counts map[PlaceholderType]int, placeholders PlaceholderMap) error {
+func validatePlaceholderSets(counts map[PlaceholderType]int, placeholders symbolMap) error {
 	for placeholder, chars := range placeholders {
 		// Only validate placeholders actually used in pattern
 		if counts[placeholder] == 0 {
 			continue
 		}
 
-		if hasDuplicates([]rune(chars)) {
+		if hasDuplicates(chars) {
 			return fmt.Errorf("placeholder '%c' contains duplicate characters", placeholder)
 		}
 
+		if err := validatePrefixFree(placeholder, chars); err != nil {
+			return err
+		}
+
 		if err := validateVowelSet(placeholder, chars); err != nil {
 			return err
 		}
@@ -226,7 +272,7 @@ func validatePlaceholderSets(counts map[PlaceholderType]int, placeholders Placeh
 }
 
 // validateVowelSet validates vowel placeholder character sets.
-func validateVowelSet(placeholder PlaceholderType, chars RuneSet) error {
+func validateVowelSet(placeholder PlaceholderType, chars []string) error {
 	if placeholder != Vowel {
 		return nil
 	}
@@ -235,20 +281,22 @@ func validateVowelSet(placeholder PlaceholderType, chars RuneSet) error {
 		return fmt.Errorf("vowel placeholder '%c' must have at least one character", placeholder)
 	}
 
-	for _, char := range chars {
-		if !isVowelBase(char) {
-			return fmt.Errorf(
-				"vowel placeholder '%c' contains invalid vowel '%c' (allowed: a,e,i,o,u,y and their diacritical variants)",
-				placeholder,
-				char,
-			)
+	for _, member := range chars {
+		for _, char := range member {
+			if !isVowelBase(char) {
+				return fmt.Errorf(
+					"vowel placeholder '%c' contains invalid vowel '%c' (allowed: a,e,i,o,u,y and their diacritical variants)",
+					placeholder,
+					char,
+				)
+			}
 		}
 	}
 	return nil
 }
 
 // validateMinimumSize checks minimum character requirements for placeholders.
-func validateMinimumSize(placeholder PlaceholderType, chars RuneSet) error {
+func validateMinimumSize(placeholder PlaceholderType, chars []string) error {
 	if placeholder == Vowel && len(chars) < MinCharsForVowel {
 		return fmt.Errorf("vowel placeholder needs at least %d characters, got %d",
 			MinCharsForVowel, len(chars))
@@ -257,7 +305,7 @@ func validateMinimumSize(placeholder PlaceholderType, chars RuneSet) error {
 }
 
 // validatePatternRequirements checks pattern has required placeholder types.
-func validatePatternRequirements(counts map[PlaceholderType]int, placeholders PlaceholderMap) error {
+func validatePatternRequirements(counts map[PlaceholderType]int, placeholders symbolMap) error {
 	if err := requireVowel(counts); err != nil {
 		return err
 	}
@@ -283,7 +331,7 @@ func requireVowel(counts map[PlaceholderType]int) error {
 }
 
 // requireMinimalComplement ensures sufficient non-vowel variety.
-func requireMinimalComplement(counts map[PlaceholderType]int, placeholders PlaceholderMap) error {
+func requireMinimalComplement(counts map[PlaceholderType]int, placeholders symbolMap) error {
 	for placeholder := range counts {
 		if isComplementPlaceholder(placeholder) &&
 			len(placeholders[placeholder]) >= MinCharsForComplement {
@@ -304,7 +352,7 @@ func requireMinimalComplement(counts map[PlaceholderType]int, placeholders Place
 }
 
 // validateNoOverlaps checks for character overlap between placeholders.
-func validateNoOverlaps(counts map[PlaceholderType]int, placeholders PlaceholderMap) error {
+func validateNoOverlaps(counts map[PlaceholderType]int, placeholders symbolMap) error {
 	allPlaceholders := make([]PlaceholderType, 0, len(counts))
 	for p := range counts {
 		allPlaceholders = append(allPlaceholders, p)
@@ -327,10 +375,10 @@ func isComplementPlaceholder(p PlaceholderType) bool {
 	return slices.Contains(ComplementPlaceholders, p)
 }
 
-// hasDuplicates checks if a rune slice contains duplicates.
-func hasDuplicates(runes []rune) bool {
-	seen := make(map[rune]bool)
-	for _, r := range runes {
+// hasDuplicates checks if a slice contains duplicates.
+func hasDuplicates[T comparable](members []T) bool {
+	seen := make(map[T]bool)
+	for _, r := range members {
 		if seen[r] {
 			return true
 		}
@@ -339,9 +387,9 @@ func hasDuplicates(runes []rune) bool {
 	return false
 }
 
-// hasOverlap checks if two rune slices have any common elements.
-func hasOverlap(a, b []rune) bool {
-	set := make(map[rune]bool)
+// hasOverlap checks if two slices have any common elements.
+func hasOverlap[T comparable](a, b []T) bool {
+	set := make(map[T]bool)
 	for _, r := range a {
 		set[r] = true
 	}
@@ -0,0 +1,273 @@
+// Package phonidgrpc converts protobuf string fields annotated with
+// (phonid.profile) between decimal numbers inside a service and phonid words
+// on the wire, using gRPC interceptors.
+//
+// Annotate public ID fields in your .proto files:
+//
+//	import "phonid/options.proto";
+//
+//	message GetOrderRequest {
+//	  string id = 1 [(phonid.profile) = "orders"];
+//	}
+//
+// Server interceptors decode incoming words to numbers before the handler
+// runs and encode numbers in responses; client interceptors do the reverse.
+// Handlers and callers therefore see "1337" where the wire carries a word.
+package phonidgrpc
+
+//go:generate sh -c "cd ../../proto && protoc --go_out=../ --go_opt=module=github.com/iilei/phonid --go-grpc_out=../ --go-grpc_opt=module=github.com/iilei/phonid phonid/options.proto phonid/testpb/orders.proto"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+const (
+	toWords   direction = iota // Numbers become words before a message is sent
+	toNumbers                  // Words become numbers after a message is received
+)
+
+type (
+	// Codecs maps the profile names used in (phonid.profile) options to codecs.
+	Codecs map[string]phonid.WordCodec
+
+	// direction selects the conversion applied to annotated fields.
+	direction int
+
+	// serverStream converts messages flowing through a server stream.
+	serverStream struct {
+		grpc.ServerStream
+		codecs Codecs
+	}
+
+	// clientStream converts messages flowing through a client stream.
+	clientStream struct {
+		grpc.ClientStream
+		codecs Codecs
+	}
+)
+
+// UnaryServerInterceptor decodes request words and encodes response numbers.
+// Invalid words are rejected with codes.InvalidArgument.
+func UnaryServerInterceptor(codecs Codecs) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := codecs.convert(req, toNumbers, codes.InvalidArgument); err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return codecs.convertCopy(resp, toWords, codes.Internal)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor(codecs Codecs) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, codecs: codecs})
+	}
+}
+
+// UnaryClientInterceptor encodes request numbers and decodes response words.
+// The caller's request message is not modified.
+func UnaryClientInterceptor(codecs Codecs) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		converted, err := codecs.convertCopy(req, toWords, codes.InvalidArgument)
+		if err != nil {
+			return err
+		}
+		if err := invoker(ctx, method, converted, reply, cc, opts...); err != nil {
+			return err
+		}
+		return codecs.convert(reply, toNumbers, codes.Internal)
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streaming RPCs.
+func StreamClientInterceptor(codecs Codecs) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, codecs: codecs}, nil
+	}
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.codecs.convert(m, toNumbers, codes.InvalidArgument)
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	converted, err := s.codecs.convertCopy(m, toWords, codes.Internal)
+	if err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(converted)
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	converted, err := s.codecs.convertCopy(m, toWords, codes.InvalidArgument)
+	if err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(converted)
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.codecs.convert(m, toNumbers, codes.Internal)
+}
+
+// convertCopy converts a clone of m, leaving the caller's message untouched.
+func (c Codecs) convertCopy(m any, dir direction, code codes.Code) (any, error) {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return m, nil
+	}
+	clone := proto.Clone(message)
+	if err := c.convert(clone, dir, code); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// convert rewrites the annotated fields of m in place. Failures become a
+// status error with the given code.
+func (c Codecs) convert(m any, dir direction, code codes.Code) error {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := c.convertMessage(message.ProtoReflect(), dir); err != nil {
+		return status.Error(code, err.Error())
+	}
+	return nil
+}
+
+// convertMessage walks the populated fields of message, descending into
+// nested messages, lists and maps.
+func (c Codecs) convertMessage(message protoreflect.Message, dir direction) error {
+	var err error
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		err = c.convertField(message, field, value, dir)
+		return err == nil
+	})
+	return err
+}
+
+// convertField converts one populated field.
+func (c Codecs) convertField(message protoreflect.Message, field protoreflect.FieldDescriptor,
+	value protoreflect.Value, dir direction,
+) error {
+	switch {
+	case field.IsMap():
+		if field.MapValue().Message() == nil {
+			return nil
+		}
+		var err error
+		value.Map().Range(func(_ protoreflect.MapKey, entry protoreflect.Value) bool {
+			err = c.convertMessage(entry.Message(), dir)
+			return err == nil
+		})
+		return err
+
+	case field.Message() != nil:
+		if !field.IsList() {
+			return c.convertMessage(value.Message(), dir)
+		}
+		list := value.List()
+		for i := range list.Len() {
+			if err := c.convertMessage(list.Get(i).Message(), dir); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case field.Kind() == protoreflect.StringKind:
+		return c.convertStrings(message, field, value, dir)
+	}
+	return nil
+}
+
+// convertStrings converts an annotated singular or repeated string field.
+func (c Codecs) convertStrings(message protoreflect.Message, field protoreflect.FieldDescriptor,
+	value protoreflect.Value, dir direction,
+) error {
+	profile := Profile(field)
+	if profile == "" {
+		return nil
+	}
+	codec, ok := c[profile]
+	if !ok {
+		return fmt.Errorf("%s: no codec for profile %q", field.FullName(), profile)
+	}
+
+	if !field.IsList() {
+		converted, err := convertString(codec, value.String(), dir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.FullName(), err)
+		}
+		message.Set(field, protoreflect.ValueOfString(converted))
+		return nil
+	}
+	list := value.List()
+	for i := range list.Len() {
+		converted, err := convertString(codec, list.Get(i).String(), dir)
+		if err != nil {
+			return fmt.Errorf("%s[%d]: %w", field.FullName(), i, err)
+		}
+		list.Set(i, protoreflect.ValueOfString(converted))
+	}
+	return nil
+}
+
+// Profile returns the (phonid.profile) option of field, or "" if it has none.
+func Profile(field protoreflect.FieldDescriptor) string {
+	options := field.Options()
+	if options == nil || !proto.HasExtension(options, E_Profile) {
+		return ""
+	}
+	profile, _ := proto.GetExtension(options, E_Profile).(string)
+	return profile
+}
+
+// convertString converts one value. Empty strings are unset IDs and kept.
+func convertString(codec phonid.WordCodec, value string, dir direction) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	if dir == toNumbers {
+		number, err := codec.Decode(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(number), nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return "", fmt.Errorf("%q is not a non-negative decimal number", value)
+	}
+	return codec.Encode(phonid.PositiveInt(number))
+}
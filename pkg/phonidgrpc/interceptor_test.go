@@ -0,0 +1,230 @@
+package phonidgrpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	phonid "github.com/iilei/phonid/pkg"
+	"github.com/iilei/phonid/pkg/phonidgrpc"
+	"github.com/iilei/phonid/pkg/phonidgrpc/internal/testpb"
+)
+
+func testCodec(t *testing.T) phonid.WordCodec {
+	t.Helper()
+	config, err := phonid.NewConfigWithOptions(phonid.WithPhonetic(&phonid.PhonidConfig{
+		Patterns: []string{"CVC", "CVCVC"},
+		Placeholders: phonid.PlaceholderMap{
+			phonid.Consonant: phonid.RuneSet("bdkst"),
+			phonid.Vowel:     phonid.RuneSet("aeiou"),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := phonid.NewCodec(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codec
+}
+
+// ordersServer works with decimal numbers only and records what it received.
+type ordersServer struct {
+	testpb.UnimplementedOrdersServer
+	received []string
+}
+
+func (s *ordersServer) GetOrder(_ context.Context, req *testpb.GetOrderRequest) (*testpb.Order, error) {
+	s.received = append(s.received, req.GetId())
+	return &testpb.Order{
+		Id:         req.GetId(),
+		CustomerId: "1",
+		RelatedIds: []string{"1", "2"},
+		Note:       req.GetId(),
+		Items:      []*testpb.LineItem{{ProductId: "42", Quantity: 3}},
+	}, nil
+}
+
+func (s *ordersServer) ListOrders(req *testpb.ListOrdersRequest, stream grpc.ServerStreamingServer[testpb.Order]) error {
+	s.received = append(s.received, req.GetCustomerId())
+	for _, id := range []string{"1", "2"} {
+		if err := stream.Send(&testpb.Order{Id: id, CustomerId: req.GetCustomerId()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startServer serves orders in-process with the server interceptors and
+// returns a dial function for clients with extra options.
+func startServer(
+	t *testing.T, codecs phonidgrpc.Codecs, orders *ordersServer,
+) func(...grpc.DialOption) testpb.OrdersClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(phonidgrpc.UnaryServerInterceptor(codecs)),
+		grpc.StreamInterceptor(phonidgrpc.StreamServerInterceptor(codecs)),
+	)
+	testpb.RegisterOrdersServer(server, orders)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return func(options ...grpc.DialOption) testpb.OrdersClient {
+		options = append(options,
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		conn, err := grpc.NewClient("passthrough:///bufnet", options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		return testpb.NewOrdersClient(conn)
+	}
+}
+
+func testCodecs(t *testing.T) phonidgrpc.Codecs {
+	t.Helper()
+	codec := testCodec(t)
+	return phonidgrpc.Codecs{"orders": codec, "customers": codec, "products": codec}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	orders := &ordersServer{}
+	client := startServer(t, testCodecs(t), orders)()
+
+	order, err := client.GetOrder(context.Background(), &testpb.GetOrderRequest{Id: "dok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(orders.received, []string{"42"}) {
+		t.Errorf("handler received %v, want [42]", orders.received)
+	}
+	if order.GetId() != "dok" || order.GetCustomerId() != "bad" ||
+		!slices.Equal(order.GetRelatedIds(), []string{"bad", "bak"}) || order.GetItems()[0].GetProductId() != "dok" {
+		t.Errorf("response IDs were not encoded: %v", order)
+	}
+	if order.GetNote() != "42" || order.GetItems()[0].GetQuantity() != 3 {
+		t.Errorf("unannotated fields changed: %v", order)
+	}
+}
+
+func TestUnaryServerInterceptor_InvalidWord(t *testing.T) {
+	orders := &ordersServer{}
+	client := startServer(t, testCodecs(t), orders)()
+
+	_, err := client.GetOrder(context.Background(), &testpb.GetOrderRequest{Id: "xyz"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error = %v, want InvalidArgument", err)
+	}
+	if len(orders.received) != 0 {
+		t.Errorf("handler ran with %v", orders.received)
+	}
+}
+
+func TestUnaryServerInterceptor_UnknownProfile(t *testing.T) {
+	client := startServer(t, phonidgrpc.Codecs{"orders": testCodec(t)}, &ordersServer{})()
+
+	_, err := client.GetOrder(context.Background(), &testpb.GetOrderRequest{Id: "dok"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("error = %v, want Internal for the missing customers codec", err)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	orders := &ordersServer{}
+	client := startServer(t, testCodecs(t), orders)()
+
+	stream, err := client.ListOrders(context.Background(), &testpb.ListOrdersRequest{CustomerId: "dok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for {
+		order, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.GetCustomerId() != "dok" {
+			t.Errorf("customer_id = %q, want dok", order.GetCustomerId())
+		}
+		ids = append(ids, order.GetId())
+	}
+	if !slices.Equal(ids, []string{"bad", "bak"}) {
+		t.Errorf("ids = %v, want [bad bak]", ids)
+	}
+	if !slices.Equal(orders.received, []string{"42"}) {
+		t.Errorf("handler received %v, want [42]", orders.received)
+	}
+}
+
+func TestClientInterceptors(t *testing.T) {
+	codecs := testCodecs(t)
+	orders := &ordersServer{}
+	client := startServer(t, codecs, orders)(
+		grpc.WithUnaryInterceptor(phonidgrpc.UnaryClientInterceptor(codecs)),
+		grpc.WithStreamInterceptor(phonidgrpc.StreamClientInterceptor(codecs)),
+	)
+
+	req := &testpb.GetOrderRequest{Id: "42"}
+	order, err := client.GetOrder(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetId() != "42" {
+		t.Errorf("request was modified: id = %q", req.GetId())
+	}
+	if order.GetId() != "42" || order.GetCustomerId() != "1" || order.GetItems()[0].GetProductId() != "42" {
+		t.Errorf("response IDs were not decoded: %v", order)
+	}
+
+	stream, err := client.ListOrders(context.Background(), &testpb.ListOrdersRequest{CustomerId: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for {
+		order, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, order.GetId())
+	}
+	if !slices.Equal(ids, []string{"1", "2"}) {
+		t.Errorf("ids = %v, want [1 2]", ids)
+	}
+	if !slices.Equal(orders.received, []string{"42", "42"}) {
+		t.Errorf("handler received %v, want [42 42]", orders.received)
+	}
+}
+
+func TestUnaryClientInterceptor_NotANumber(t *testing.T) {
+	codecs := testCodecs(t)
+	client := startServer(t, codecs, &ordersServer{})(
+		grpc.WithUnaryInterceptor(phonidgrpc.UnaryClientInterceptor(codecs)),
+	)
+
+	_, err := client.GetOrder(context.Background(), &testpb.GetOrderRequest{Id: "dok"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error = %v, want InvalidArgument", err)
+	}
+}
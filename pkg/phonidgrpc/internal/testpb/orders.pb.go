@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: phonid/testpb/orders.proto
+
+package testpb
+
+import (
+	_ "github.com/iilei/phonid/pkg/phonidgrpc"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_phonid_testpb_orders_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_phonid_testpb_orders_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_phonid_testpb_orders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetOrderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_phonid_testpb_orders_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_phonid_testpb_orders_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_phonid_testpb_orders_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListOrdersRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	RelatedIds    []string               `protobuf:"bytes,3,rep,name=related_ids,json=relatedIds,proto3" json:"related_ids,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	Items         []*LineItem            `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_phonid_testpb_orders_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_phonid_testpb_orders_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_phonid_testpb_orders_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Order) GetRelatedIds() []string {
+	if x != nil {
+		return x.RelatedIds
+	}
+	return nil
+}
+
+func (x *Order) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*LineItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type LineItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineItem) Reset() {
+	*x = LineItem{}
+	mi := &file_phonid_testpb_orders_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineItem) ProtoMessage() {}
+
+func (x *LineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_phonid_testpb_orders_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineItem.ProtoReflect.Descriptor instead.
+func (*LineItem) Descriptor() ([]byte, []int) {
+	return file_phonid_testpb_orders_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LineItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LineItem) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+var File_phonid_testpb_orders_proto protoreflect.FileDescriptor
+
+const file_phonid_testpb_orders_proto_rawDesc = "" +
+	"\n" +
+	"\x1aphonid/testpb/orders.proto\x12\rphonid.testpb\x1a\x14phonid/options.proto\"-\n" +
+	"\x0fGetOrderRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"ڊ\x19\x06ordersR\x02id\"C\n" +
+	"\x11ListOrdersRequest\x12.\n" +
+	"\vcustomer_id\x18\x01 \x01(\tB\rڊ\x19\tcustomersR\n" +
+	"customerId\"\xc3\x01\n" +
+	"\x05Order\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"ڊ\x19\x06ordersR\x02id\x12.\n" +
+	"\vcustomer_id\x18\x02 \x01(\tB\rڊ\x19\tcustomersR\n" +
+	"customerId\x12+\n" +
+	"\vrelated_ids\x18\x03 \x03(\tB\n" +
+	"ڊ\x19\x06ordersR\n" +
+	"relatedIds\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12-\n" +
+	"\x05items\x18\x05 \x03(\v2\x17.phonid.testpb.LineItemR\x05items\"S\n" +
+	"\bLineItem\x12+\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\fڊ\x19\bproductsR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\rR\bquantity2\x92\x01\n" +
+	"\x06Orders\x12@\n" +
+	"\bGetOrder\x12\x1e.phonid.testpb.GetOrderRequest\x1a\x14.phonid.testpb.Order\x12F\n" +
+	"\n" +
+	"ListOrders\x12 .phonid.testpb.ListOrdersRequest\x1a\x14.phonid.testpb.Order0\x01B8Z6github.com/iilei/phonid/pkg/phonidgrpc/internal/testpbb\x06proto3"
+
+var (
+	file_phonid_testpb_orders_proto_rawDescOnce sync.Once
+	file_phonid_testpb_orders_proto_rawDescData []byte
+)
+
+func file_phonid_testpb_orders_proto_rawDescGZIP() []byte {
+	file_phonid_testpb_orders_proto_rawDescOnce.Do(func() {
+		file_phonid_testpb_orders_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_phonid_testpb_orders_proto_rawDesc), len(file_phonid_testpb_orders_proto_rawDesc)))
+	})
+	return file_phonid_testpb_orders_proto_rawDescData
+}
+
+var file_phonid_testpb_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_phonid_testpb_orders_proto_goTypes = []any{
+	(*GetOrderRequest)(nil),   // 0: phonid.testpb.GetOrderRequest
+	(*ListOrdersRequest)(nil), // 1: phonid.testpb.ListOrdersRequest
+	(*Order)(nil),             // 2: phonid.testpb.Order
+	(*LineItem)(nil),          // 3: phonid.testpb.LineItem
+}
+var file_phonid_testpb_orders_proto_depIdxs = []int32{
+	3, // 0: phonid.testpb.Order.items:type_name -> phonid.testpb.LineItem
+	0, // 1: phonid.testpb.Orders.GetOrder:input_type -> phonid.testpb.GetOrderRequest
+	1, // 2: phonid.testpb.Orders.ListOrders:input_type -> phonid.testpb.ListOrdersRequest
+	2, // 3: phonid.testpb.Orders.GetOrder:output_type -> phonid.testpb.Order
+	2, // 4: phonid.testpb.Orders.ListOrders:output_type -> phonid.testpb.Order
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_phonid_testpb_orders_proto_init() }
+func file_phonid_testpb_orders_proto_init() {
+	if File_phonid_testpb_orders_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_phonid_testpb_orders_proto_rawDesc), len(file_phonid_testpb_orders_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_phonid_testpb_orders_proto_goTypes,
+		DependencyIndexes: file_phonid_testpb_orders_proto_depIdxs,
+		MessageInfos:      file_phonid_testpb_orders_proto_msgTypes,
+	}.Build()
+	File_phonid_testpb_orders_proto = out.File
+	file_phonid_testpb_orders_proto_goTypes = nil
+	file_phonid_testpb_orders_proto_depIdxs = nil
+}
@@ -0,0 +1,85 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: phonid/options.proto
+
+package phonidgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_phonid_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51371,
+		Name:          "phonid.profile",
+		Tag:           "bytes,51371,opt,name=profile",
+		Filename:      "phonid/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Name of the codec profile that converts this string field between a
+	// decimal number (inside the service) and a phonid word (on the wire).
+	//
+	// optional string profile = 51371;
+	E_Profile = &file_phonid_options_proto_extTypes[0]
+)
+
+var File_phonid_options_proto protoreflect.FileDescriptor
+
+const file_phonid_options_proto_rawDesc = "" +
+	"\n" +
+	"\x14phonid/options.proto\x12\x06phonid\x1a google/protobuf/descriptor.proto:9\n" +
+	"\aprofile\x12\x1d.google.protobuf.FieldOptions\x18\xab\x91\x03 \x01(\tR\aprofileB(Z&github.com/iilei/phonid/pkg/phonidgrpcb\x06proto3"
+
+var file_phonid_options_proto_goTypes = []any{
+	(*descriptorpb.FieldOptions)(nil), // 0: google.protobuf.FieldOptions
+}
+var file_phonid_options_proto_depIdxs = []int32{
+	0, // 0: phonid.profile:extendee -> google.protobuf.FieldOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_phonid_options_proto_init() }
+func file_phonid_options_proto_init() {
+	if File_phonid_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_phonid_options_proto_rawDesc), len(file_phonid_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_phonid_options_proto_goTypes,
+		DependencyIndexes: file_phonid_options_proto_depIdxs,
+		ExtensionInfos:    file_phonid_options_proto_extTypes,
+	}.Build()
+	File_phonid_options_proto = out.File
+	file_phonid_options_proto_goTypes = nil
+	file_phonid_options_proto_depIdxs = nil
+}
@@ -0,0 +1,251 @@
+// Package phonidhttp decodes phonid path and query parameters in net/http
+// handlers, including Go 1.22+ http.ServeMux wildcards.
+package phonidhttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem responses.
+const ProblemContentType = "application/problem+json"
+
+type (
+	// DecodeError describes a parameter that is not a valid word.
+	DecodeError struct {
+		Param  string // Parameter name
+		Value  string // Value as received
+		Status int    // HTTP status of the problem response
+		Err    error  // Error from the codec
+	}
+
+	// Problem is an RFC 7807 problem document with the rejected parameter as
+	// extension members.
+	Problem struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+		Param  string `json:"param"`
+		Value  string `json:"value"`
+	}
+
+	// ErrorHandler writes the response for a parameter that failed to decode.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err *DecodeError)
+
+	// Option configures the middleware.
+	Option func(*options)
+
+	options struct {
+		redirectNumeric bool
+		errorHandler    ErrorHandler
+	}
+
+	// paramSource reads and rewrites one kind of parameter.
+	paramSource struct {
+		status  int
+		read    func(r *http.Request, name string) string
+		rewrite func(u *url.URL, pattern, name, word string) bool
+	}
+
+	// contextKey stores the decoded number of one parameter.
+	contextKey struct{ name string }
+)
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %v", e.Param, e.Value, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// WithNumericRedirect redirects requests that carry a plain number instead of
+// a word to the canonical word URL: 301 for GET and HEAD, 308 otherwise so
+// the method and body are kept.
+func WithNumericRedirect() Option {
+	return func(o *options) {
+		o.redirectNumeric = true
+	}
+}
+
+// WithErrorHandler replaces the default RFC 7807 problem response.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// PathParam returns middleware that decodes the {name} wildcard of the
+// matched http.ServeMux pattern and stores the number in the request
+// context. Invalid words are answered with 404 Not Found.
+//
+//	mux.Handle("GET /orders/{id}", phonidhttp.PathParam(codec, "id")(orders))
+func PathParam(codec phonid.WordCodec, name string, opts ...Option) func(http.Handler) http.Handler {
+	return middleware(codec, name, paramSource{
+		status:  http.StatusNotFound,
+		read:    (*http.Request).PathValue,
+		rewrite: rewritePath,
+	}, opts)
+}
+
+// QueryParam returns middleware that decodes the ?name= query parameter and
+// stores the number in the request context. A missing parameter is passed
+// through; invalid words are answered with 400 Bad Request.
+func QueryParam(codec phonid.WordCodec, name string, opts ...Option) func(http.Handler) http.Handler {
+	return middleware(codec, name, paramSource{
+		status: http.StatusBadRequest,
+		read: func(r *http.Request, name string) string {
+			return r.URL.Query().Get(name)
+		},
+		rewrite: rewriteQuery,
+	}, opts)
+}
+
+// Number returns the number decoded for the named parameter.
+func Number(ctx context.Context, name string) (int, bool) {
+	number, ok := ctx.Value(contextKey{name}).(int)
+	return number, ok
+}
+
+// WithNumber returns a context carrying number for the named parameter,
+// e.g. for testing handlers without the middleware.
+func WithNumber(ctx context.Context, name string, number int) context.Context {
+	return context.WithValue(ctx, contextKey{name}, number)
+}
+
+// WriteProblem writes err as an RFC 7807 problem response.
+func WriteProblem(w http.ResponseWriter, _ *http.Request, err *DecodeError) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(err.Status)
+	_ = json.NewEncoder(w).Encode(Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(err.Status),
+		Status: err.Status,
+		Detail: err.Error(),
+		Param:  err.Param,
+		Value:  err.Value,
+	})
+}
+
+// middleware decodes one parameter before calling next.
+func middleware(
+	codec phonid.WordCodec, name string, source paramSource, opts []Option,
+) func(http.Handler) http.Handler {
+	o := options{errorHandler: WriteProblem}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := source.read(r, name)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			number, err := codec.Decode(value)
+			if err == nil {
+				next.ServeHTTP(w, r.WithContext(WithNumber(r.Context(), name, number)))
+				return
+			}
+
+			// Words win over numbers, so alphabets containing digits still work
+			if o.redirectNumeric && isNumeric(value) {
+				if redirectNumeric(w, r, codec, name, value, source) {
+					return
+				}
+			}
+
+			o.errorHandler(w, r, &DecodeError{Param: name, Value: value, Status: source.status, Err: err})
+		})
+	}
+}
+
+// redirectNumeric redirects to the URL with value replaced by its word and
+// reports whether it did.
+func redirectNumeric(
+	w http.ResponseWriter, r *http.Request, codec phonid.WordCodec, name, value string, source paramSource,
+) bool {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return false
+	}
+	word, err := codec.Encode(phonid.PositiveInt(number))
+	if err != nil {
+		return false
+	}
+
+	target := *r.URL
+	if !source.rewrite(&target, r.Pattern, name, word) {
+		return false
+	}
+
+	status := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		status = http.StatusMovedPermanently
+	}
+	http.Redirect(w, r, target.RequestURI(), status)
+	return true
+}
+
+// rewritePath replaces the path segment matched by the {name} wildcard of
+// pattern with word.
+func rewritePath(u *url.URL, pattern, name, word string) bool {
+	index := wildcardSegment(pattern, name)
+	segments := strings.Split(u.EscapedPath(), "/")
+	if index < 0 || index >= len(segments) {
+		return false
+	}
+
+	segments[index] = url.PathEscape(word)
+	escaped := strings.Join(segments, "/")
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return false
+	}
+	u.Path, u.RawPath = path, escaped
+	return true
+}
+
+// wildcardSegment returns the index of the path segment holding the {name}
+// wildcard of a ServeMux pattern, or -1.
+func wildcardSegment(pattern, name string) int {
+	// Patterns are "[METHOD ][HOST]/[PATH]"
+	if _, rest, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimLeft(rest, " \t")
+	}
+	slash := strings.Index(pattern, "/")
+	if slash < 0 {
+		return -1
+	}
+
+	for i, segment := range strings.Split(pattern[slash:], "/") {
+		if segment == "{"+name+"}" {
+			return i
+		}
+	}
+	return -1
+}
+
+// rewriteQuery replaces the query parameter with word.
+func rewriteQuery(u *url.URL, _, name, word string) bool {
+	query := u.Query()
+	query.Set(name, word)
+	u.RawQuery = query.Encode()
+	return true
+}
+
+// isNumeric reports whether value consists of ASCII digits only.
+func isNumeric(value string) bool {
+	return value != "" && strings.Trim(value, "0123456789") == ""
+}
@@ -0,0 +1,177 @@
+package phonidhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	phonid "github.com/iilei/phonid/pkg"
+	"github.com/iilei/phonid/pkg/phonidhttp"
+)
+
+func testCodec(t *testing.T) phonid.WordCodec {
+	t.Helper()
+	config, err := phonid.NewConfigWithOptions(phonid.WithPhonetic(&phonid.PhonidConfig{
+		Patterns: []string{"CVC", "CVCVC"},
+		Placeholders: phonid.PlaceholderMap{
+			phonid.Consonant: phonid.RuneSet("bdkst"),
+			phonid.Vowel:     phonid.RuneSet("aeiou"),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := phonid.NewCodec(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codec
+}
+
+// echoNumber writes the decoded number of param, or "none".
+func echoNumber(param string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		number, ok := phonidhttp.Number(r.Context(), param)
+		if !ok {
+			fmt.Fprint(w, "none")
+			return
+		}
+		fmt.Fprint(w, number)
+	})
+}
+
+func serve(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	return recorder
+}
+
+func TestPathParam(t *testing.T) {
+	codec := testCodec(t)
+	mux := http.NewServeMux()
+	mux.Handle("/orders/{id}", phonidhttp.PathParam(codec, "id", phonidhttp.WithNumericRedirect())(echoNumber("id")))
+	mux.Handle("/plain/{id}", phonidhttp.PathParam(codec, "id")(echoNumber("id")))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+		wantLoc    string
+	}{
+		{"word", http.MethodGet, "/orders/dok", http.StatusOK, "42", ""},
+		{"numeric GET redirects", http.MethodGet, "/orders/42?x=1", http.StatusMovedPermanently, "", "/orders/dok?x=1"},
+		{"numeric POST keeps method", http.MethodPost, "/orders/42", http.StatusPermanentRedirect, "", "/orders/dok"},
+		{"numeric out of range", http.MethodGet, "/orders/999999", http.StatusNotFound, "", ""},
+		{"invalid word", http.MethodGet, "/orders/xyz", http.StatusNotFound, "", ""},
+		{"numeric without redirect", http.MethodGet, "/plain/42", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(mux, tt.method, tt.target)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.wantBody != "" && recorder.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", recorder.Body, tt.wantBody)
+			}
+			if location := recorder.Header().Get("Location"); location != tt.wantLoc {
+				t.Errorf("Location = %q, want %q", location, tt.wantLoc)
+			}
+		})
+	}
+}
+
+func TestPathParam_RepeatedValue(t *testing.T) {
+	codec := testCodec(t)
+	redirect := phonidhttp.WithNumericRedirect()
+	mux := http.NewServeMux()
+	mux.Handle("GET /orders/{id}/items/{item}", phonidhttp.PathParam(codec, "id", redirect)(
+		phonidhttp.PathParam(codec, "item", redirect)(echoNumber("item"))))
+
+	tests := []struct {
+		target     string
+		wantStatus int
+		wantLoc    string
+	}{
+		{"/orders/42/items/42", http.StatusMovedPermanently, "/orders/dok/items/42"},
+		{"/orders/dok/items/42", http.StatusMovedPermanently, "/orders/dok/items/dok"},
+		{"/orders/dok/items/dok", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			recorder := serve(mux, http.MethodGet, tt.target)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %q)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if location := recorder.Header().Get("Location"); location != tt.wantLoc {
+				t.Errorf("Location = %q, want %q", location, tt.wantLoc)
+			}
+		})
+	}
+}
+
+func TestPathParam_Problem(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /orders/{id}", phonidhttp.PathParam(testCodec(t), "id")(echoNumber("id")))
+
+	recorder := serve(mux, http.MethodGet, "/orders/xyz")
+	if got := recorder.Header().Get("Content-Type"); got != phonidhttp.ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", got, phonidhttp.ProblemContentType)
+	}
+
+	var problem phonidhttp.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem document %q: %v", recorder.Body, err)
+	}
+	if problem.Status != http.StatusNotFound || problem.Param != "id" || problem.Value != "xyz" ||
+		problem.Title != "Not Found" || !strings.Contains(problem.Detail, "xyz") {
+		t.Errorf("problem = %+v", problem)
+	}
+}
+
+func TestQueryParam(t *testing.T) {
+	handler := phonidhttp.QueryParam(testCodec(t), "customer", phonidhttp.WithNumericRedirect())(echoNumber("customer"))
+
+	if recorder := serve(handler, http.MethodGet, "/search?customer=dok"); recorder.Body.String() != "42" {
+		t.Errorf("word: body = %q, want 42", recorder.Body)
+	}
+	if recorder := serve(handler, http.MethodGet, "/search"); recorder.Body.String() != "none" {
+		t.Errorf("missing: body = %q, want none", recorder.Body)
+	}
+
+	recorder := serve(handler, http.MethodGet, "/search?customer=42&page=2")
+	if recorder.Code != http.StatusMovedPermanently || recorder.Header().Get("Location") != "/search?customer=dok&page=2" {
+		t.Errorf("numeric: %d %q", recorder.Code, recorder.Header().Get("Location"))
+	}
+
+	if recorder := serve(handler, http.MethodGet, "/search?customer=xyz"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("invalid: status = %d, want 400", recorder.Code)
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	var got *phonidhttp.DecodeError
+	handler := phonidhttp.PathParam(testCodec(t), "id", phonidhttp.WithErrorHandler(
+		func(w http.ResponseWriter, _ *http.Request, err *phonidhttp.DecodeError) {
+			got = err
+			w.WriteHeader(http.StatusTeapot)
+		},
+	))(echoNumber("id"))
+
+	mux := http.NewServeMux()
+	mux.Handle("/orders/{id}", handler)
+	if recorder := serve(mux, http.MethodGet, "/orders/xyz"); recorder.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want the custom handler's", recorder.Code)
+	}
+	if got == nil || got.Param != "id" || got.Value != "xyz" || errors.Unwrap(got) == nil {
+		t.Errorf("DecodeError = %+v", got)
+	}
+}
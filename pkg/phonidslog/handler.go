@@ -0,0 +1,151 @@
+// Package phonidslog renders numeric IDs in log/slog records as phonid words.
+package phonidslog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+
+	phonid "github.com/iilei/phonid/pkg"
+)
+
+type (
+	// Options configures a Handler.
+	Options struct {
+		// Keys are the attribute keys whose values are encoded, e.g. "order_id".
+		// Keys match at any group depth.
+		Keys []string
+		// IncludeNumber renders each ID as a group holding both the word and
+		// the raw number, e.g. order_id.word=kobab order_id.number=1337.
+		IncludeNumber bool
+	}
+
+	// Handler wraps another slog.Handler and encodes the values of configured
+	// attribute keys through a codec. Values that cannot be encoded are kept
+	// as they are, so a record is never dropped because of an ID.
+	Handler struct {
+		inner   slog.Handler
+		codec   phonid.WordCodec
+		options Options
+	}
+
+	// ID is a number that logs as its phonid word. Use it where the key is
+	// not known to a Handler:
+	//
+	//	logger.Info("shipped", "order", phonidslog.ID{Codec: codec, Number: 1337})
+	ID struct {
+		Codec         phonid.WordCodec
+		Number        phonid.PositiveInt
+		IncludeNumber bool // Log a group of word and number instead of the word alone
+	}
+)
+
+// NewHandler wraps inner so that attributes named in options.Keys are encoded with codec.
+func NewHandler(inner slog.Handler, codec phonid.WordCodec, options Options) *Handler {
+	return &Handler{inner: inner, codec: codec, options: options}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle encodes the configured attributes and passes the record on.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	encoded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		encoded.AddAttrs(h.encodeAttr(attr))
+		return true
+	})
+	return h.inner.Handle(ctx, encoded)
+}
+
+// WithAttrs returns a handler whose preset attributes are already encoded.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	encoded := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		encoded[i] = h.encodeAttr(attr)
+	}
+	return &Handler{inner: h.inner.WithAttrs(encoded), codec: h.codec, options: h.options}
+}
+
+// WithGroup returns a handler that nests subsequent attributes in a group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{inner: h.inner.WithGroup(name), codec: h.codec, options: h.options}
+}
+
+// encodeAttr encodes attr if its key is configured, descending into groups.
+func (h *Handler) encodeAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		encoded := make([]slog.Attr, len(group))
+		for i, member := range group {
+			encoded[i] = h.encodeAttr(member)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(encoded...)}
+	}
+
+	if !slices.Contains(h.options.Keys, attr.Key) {
+		return attr
+	}
+	return slog.Attr{Key: attr.Key, Value: encodeValue(h.codec, attr.Value, h.options.IncludeNumber)}
+}
+
+// LogValue returns the word, or the number if it cannot be encoded.
+func (id ID) LogValue() slog.Value {
+	return encodeValue(id.Codec, slog.IntValue(int(id.Number)), id.IncludeNumber)
+}
+
+// encodeValue encodes an integer value. On failure the original value is
+// returned, or a group of the value and the error if includeNumber is set.
+func encodeValue(codec phonid.WordCodec, value slog.Value, includeNumber bool) slog.Value {
+	word, err := encodeNumber(codec, value)
+	switch {
+	case err != nil && includeNumber:
+		return slog.GroupValue(slog.Any("number", value), slog.String("error", err.Error()))
+	case err != nil:
+		return value
+	case includeNumber:
+		return slog.GroupValue(slog.String("word", word), slog.Any("number", value))
+	default:
+		return slog.StringValue(word)
+	}
+}
+
+// encodeNumber encodes signed, unsigned and decimal string values.
+func encodeNumber(codec phonid.WordCodec, value slog.Value) (string, error) {
+	if codec == nil {
+		return "", errors.New("no codec configured")
+	}
+
+	var number int64
+	switch value.Kind() {
+	case slog.KindInt64:
+		number = value.Int64()
+	case slog.KindUint64:
+		if value.Uint64() > math.MaxInt64 {
+			return "", fmt.Errorf("number %d is too large", value.Uint64())
+		}
+		// #nosec G115 -- checked against MaxInt64 above
+		number = int64(value.Uint64())
+	case slog.KindString:
+		parsed, err := strconv.ParseInt(value.String(), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a number", value.String())
+		}
+		number = parsed
+	default:
+		return "", fmt.Errorf("cannot encode a %s value", value.Kind())
+	}
+
+	if number < 0 || number > math.MaxInt {
+		return "", fmt.Errorf("number %d is out of range", number)
+	}
+	return codec.Encode(phonid.PositiveInt(number))
+}
@@ -0,0 +1,164 @@
+package phonidslog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	phonid "github.com/iilei/phonid/pkg"
+	"github.com/iilei/phonid/pkg/phonidslog"
+)
+
+func testCodec(t *testing.T) phonid.WordCodec {
+	t.Helper()
+	config, err := phonid.NewConfigWithOptions(phonid.WithPhonetic(&phonid.PhonidConfig{
+		Patterns: []string{"CVC", "CVCVC"},
+		Placeholders: phonid.PlaceholderMap{
+			phonid.Consonant: phonid.RuneSet("bdkst"),
+			phonid.Vowel:     phonid.RuneSet("aeiou"),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec, err := phonid.NewCodec(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codec
+}
+
+// logJSON logs through a Handler into a JSON handler and returns the decoded record.
+func logJSON(t *testing.T, options phonidslog.Options, log func(*slog.Logger)) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	inner := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	log(slog.New(phonidslog.NewHandler(inner, testCodec(t), options)))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log output %q: %v", buf.String(), err)
+	}
+	return record
+}
+
+func TestHandler_EncodesConfiguredKeys(t *testing.T) {
+	record := logJSON(t, phonidslog.Options{Keys: []string{"order_id"}}, func(logger *slog.Logger) {
+		logger.Info("shipped", "order_id", 42, "count", 42)
+	})
+
+	if record["order_id"] != "dok" {
+		t.Errorf("order_id = %v, want %q", record["order_id"], "dok")
+	}
+	if record["count"] != float64(42) {
+		t.Errorf("count = %v, want it untouched", record["count"])
+	}
+}
+
+func TestHandler_AttrsGroupsAndValues(t *testing.T) {
+	record := logJSON(t, phonidslog.Options{Keys: []string{"order_id"}}, func(logger *slog.Logger) {
+		logger.With("order_id", uint64(1)).
+			WithGroup("request").
+			Info("shipped", slog.Group("items", "order_id", "2"))
+	})
+
+	if record["order_id"] != "bad" {
+		t.Errorf("preset order_id = %v, want %q", record["order_id"], "bad")
+	}
+	request, _ := record["request"].(map[string]any)
+	items, _ := request["items"].(map[string]any)
+	if items["order_id"] != "bak" {
+		t.Errorf("grouped order_id = %v, want %q", items["order_id"], "bak")
+	}
+}
+
+func TestHandler_IncludeNumber(t *testing.T) {
+	record := logJSON(t, phonidslog.Options{Keys: []string{"order_id"}, IncludeNumber: true}, func(logger *slog.Logger) {
+		logger.Info("shipped", "order_id", 42)
+	})
+
+	id, _ := record["order_id"].(map[string]any)
+	if id["word"] != "dok" || id["number"] != float64(42) {
+		t.Errorf("order_id = %v, want word and number", record["order_id"])
+	}
+}
+
+func TestHandler_FailuresKeepTheRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		options phonidslog.Options
+		value   any
+		check   func(t *testing.T, got any)
+	}{
+		{"out of range", phonidslog.Options{}, 1 << 40, func(t *testing.T, got any) {
+			if got != float64(1<<40) {
+				t.Errorf("order_id = %v, want the raw number", got)
+			}
+		}},
+		{"negative", phonidslog.Options{}, -1, func(t *testing.T, got any) {
+			if got != float64(-1) {
+				t.Errorf("order_id = %v, want the raw number", got)
+			}
+		}},
+		{"not a number", phonidslog.Options{}, "abc", func(t *testing.T, got any) {
+			if got != "abc" {
+				t.Errorf("order_id = %v, want the raw value", got)
+			}
+		}},
+		{"with number", phonidslog.Options{IncludeNumber: true}, -1, func(t *testing.T, got any) {
+			id, _ := got.(map[string]any)
+			if id["number"] != float64(-1) || !strings.Contains(id["error"].(string), "out of range") {
+				t.Errorf("order_id = %v, want number and error", got)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Keys = []string{"order_id"}
+			record := logJSON(t, tt.options, func(logger *slog.Logger) {
+				logger.Info("shipped", "order_id", tt.value)
+			})
+			if record["msg"] != "shipped" {
+				t.Fatalf("record was not logged: %v", record)
+			}
+			tt.check(t, record["order_id"])
+		})
+	}
+}
+
+func TestHandler_Enabled(t *testing.T) {
+	inner := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn})
+	handler := phonidslog.NewHandler(inner, testCodec(t), phonidslog.Options{})
+	if handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled(Info) = true, want the inner handler's decision")
+	}
+}
+
+func TestID_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	codec := testCodec(t)
+
+	logger.Info("shipped",
+		"order", phonidslog.ID{Codec: codec, Number: 42},
+		"both", phonidslog.ID{Codec: codec, Number: 42, IncludeNumber: true},
+		"broken", phonidslog.ID{Codec: codec, Number: 1 << 40},
+	)
+
+	for _, want := range []string{"order=dok", "both.word=dok", "both.number=42", "broken=1099511627776"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output %q lacks %q", buf.String(), want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package phonid
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const pairLength = 2
+
+type (
+	// PhonotacticRules restricts which characters may follow each other.
+	// Words violating the rules are never produced: encoders rank only the
+	// permitted words, so the mapping stays bijective without rejection sampling.
+	// For syllables, the rules apply to the characters at the junction, i.e. the
+	// last character of one syllable and the first character of the next.
+	//
+	//	rules := PhonotacticRules{
+	//	    ForbiddenPairs:  []string{"xq", "zk"},
+	//	    AllowedClusters: map[string][]string{"CC": {"st", "tr", "pl"}},
+	//	}
+	PhonotacticRules struct {
+		ForbiddenPairs  []string            // Adjacent character pairs that may never occur
+		AllowedClusters map[string][]string // Placeholder pair (e.g. "CC") -> the only clusters allowed there
+	}
+)
+
+// IsZero reports whether no rules are configured.
+func (pr *PhonotacticRules) IsZero() bool {
+	return len(pr.ForbiddenPairs) == 0 && len(pr.AllowedClusters) == 0
+}
+
+// validate checks that every rule refers to known placeholders and characters.
+func (pr *PhonotacticRules) validate(placeholders symbolMap) error {
+	for _, pair := range pr.ForbiddenPairs {
+		if len([]rune(pair)) != pairLength {
+			return fmt.Errorf("forbidden pair %q must be exactly two characters", pair)
+		}
+	}
+
+	for key, clusters := range pr.AllowedClusters {
+		keyRunes := []rune(key)
+		if len(keyRunes) != pairLength {
+			return fmt.Errorf("cluster key %q must be exactly two placeholders", key)
+		}
+
+		first, firstOK := placeholders[PlaceholderType(keyRunes[0])]
+		second, secondOK := placeholders[PlaceholderType(keyRunes[1])]
+		if !firstOK || !secondOK {
+			return fmt.Errorf("cluster key %q refers to a placeholder without character set", key)
+		}
+
+		for _, cluster := range clusters {
+			runes := []rune(cluster)
+			if len(runes) != pairLength {
+				return fmt.Errorf("cluster %q for %q must be exactly two characters", cluster, key)
+			}
+			endsFirst := slices.ContainsFunc(first, func(s string) bool { return lastRune(s) == runes[0] })
+			startsSecond := slices.ContainsFunc(second, func(s string) bool { return firstRune(s) == runes[1] })
+			if !endsFirst || !startsSecond {
+				return fmt.Errorf("cluster %q is not valid for placeholders %q", cluster, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// allows reports whether char a may be directly followed by char b, given the
+// placeholders of their positions.
+func (pr *PhonotacticRules) allows(placeholderA, placeholderB string, a, b rune) bool {
+	pair := string([]rune{a, b})
+	if slices.Contains(pr.ForbiddenPairs, pair) {
+		return false
+	}
+
+	clusters, constrained := pr.AllowedClusters[placeholderA+placeholderB]
+	if constrained {
+		return slices.Contains(clusters, pair)
+	}
+
+	return true
+}
+
+// applyPhonotactics restricts the encoder to words satisfying the rules and
+// recomputes its capacity via suffix counts.
+func (e *PatternEncoder) applyPhonotactics(rules *PhonotacticRules) error {
+	if rules == nil || rules.IsZero() {
+		return nil
+	}
+
+	// transitions[i][prev][cur] reports whether positions i-1 and i may hold prev and cur
+	transitions := make([][][]bool, len(e.positions))
+	for i := 1; i < len(e.positions); i++ {
+		prevPos, curPos := e.positions[i-1], e.positions[i]
+		transitions[i] = make([][]bool, prevPos.base)
+		for p, prevSymbol := range prevPos.symbols {
+			transitions[i][p] = make([]bool, curPos.base)
+			for c, curSymbol := range curPos.symbols {
+				transitions[i][p][c] = rules.allows(
+					prevPos.placeholder, curPos.placeholder, lastRune(prevSymbol), firstRune(curSymbol))
+			}
+		}
+	}
+
+	// suffixCounts[i][c] counts valid completions from position i holding char c
+	last := len(e.positions) - 1
+	suffixCounts := make([][]int, len(e.positions))
+	suffixCounts[last] = make([]int, e.positions[last].base)
+	for c := range suffixCounts[last] {
+		suffixCounts[last][c] = 1
+	}
+	for i := last - 1; i >= 0; i-- {
+		suffixCounts[i] = make([]int, e.positions[i].base)
+		for c := range suffixCounts[i] {
+			for next, count := range suffixCounts[i+1] {
+				if transitions[i+1][c][next] {
+					suffixCounts[i][c] += count
+				}
+			}
+		}
+	}
+
+	total := 0
+	for _, count := range suffixCounts[0] {
+		total += count
+	}
+	if total == 0 {
+		return fmt.Errorf("pattern '%s' admits no words under the phonotactic rules", e.pattern)
+	}
+
+	e.transitions = transitions
+	e.suffixCounts = suffixCounts
+	e.totalCombinations = PositiveInt(total)
+	return nil
+}
+
+// unrank converts a number to the word at that rank among permitted words.
+func (e *PatternEncoder) unrank(number PositiveInt) string {
+	var word strings.Builder
+	remaining := int(number)
+	prev := -1
+
+	for i, position := range e.positions {
+		for idx, symbol := range position.symbols {
+			if i > 0 && !e.transitions[i][prev][idx] {
+				continue
+			}
+			count := e.suffixCounts[i][idx]
+			if remaining < count {
+				word.WriteString(symbol)
+				prev = idx
+				break
+			}
+			remaining -= count
+		}
+	}
+
+	return word.String()
+}
+
+// rank converts the symbol indices of a permitted word back to its number.
+func (e *PatternEncoder) rank(indices []int) (int, error) {
+	result := 0
+	prev := -1
+
+	for i, charIndex := range indices {
+		if i > 0 && !e.transitions[i][prev][charIndex] {
+			return 0, fmt.Errorf(
+				"characters '%s%s' at position %d violate phonotactic rules",
+				e.positions[i-1].symbols[prev],
+				e.positions[i].symbols[charIndex],
+				i-1,
+			)
+		}
+
+		for idx := range charIndex {
+			if i == 0 || e.transitions[i][prev][idx] {
+				result += e.suffixCounts[i][idx]
+			}
+		}
+		prev = charIndex
+	}
+
+	return result, nil
+}
+
+// firstRune returns the first rune of s.
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// lastRune returns the last rune of s.
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
@@ -0,0 +1,186 @@
+package phonid_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func phonotacticConfig() *PhonidConfig {
+	return &PhonidConfig{
+		Patterns: []string{"VCCVC"},
+		Placeholders: PlaceholderMap{
+			Vowel:     RuneSet("aeiou"),
+			Consonant: RuneSet("bkpstxz"),
+		},
+		Phonotactics: PhonotacticRules{
+			ForbiddenPairs:  []string{"zb", "ux"},
+			AllowedClusters: map[string][]string{"CC": {"st", "sp", "sk", "ks", "ts", "pt"}},
+		},
+	}
+}
+
+// permitted reports whether a word satisfies the rules of phonotacticConfig.
+func permitted(word string) bool {
+	runes := []rune(word)
+	clusters := []string{"st", "sp", "sk", "ks", "ts", "pt"}
+	for i := 1; i < len(runes); i++ {
+		pair := string(runes[i-1 : i+1])
+		if pair == "zb" || pair == "ux" {
+			return false
+		}
+	}
+	return slices.Contains(clusters, string(runes[1:3]))
+}
+
+func TestPhonotactics_CompleteBijection(t *testing.T) {
+	config := phonotacticConfig()
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	// Brute-force the number of permitted words
+	want := 0
+	vowels, consonants := "aeiou", "bkpstxz"
+	for _, a := range vowels {
+		for _, b := range consonants {
+			for _, c := range consonants {
+				for _, d := range vowels {
+					for _, e := range consonants {
+						if permitted(string([]rune{a, b, c, d, e})) {
+							want++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	capacity := encoder.Stats().TotalCombinations
+	if int(capacity) != want {
+		t.Fatalf("capacity = %d, want %d", capacity, want)
+	}
+
+	seen := make(map[string]bool)
+	previous := ""
+	for n := range capacity {
+		word, err := encoder.Encode(n)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", n, err)
+		}
+		if !permitted(word) {
+			t.Fatalf("Encode(%d) = %q violates phonotactic rules", n, word)
+		}
+		if seen[word] {
+			t.Fatalf("Encode(%d) = %q is a duplicate", n, word)
+		}
+		seen[word] = true
+
+		// Ranking preserves the lexicographic order of the alphabets
+		if previous != "" && strings.Compare(alphabetKey(previous), alphabetKey(word)) >= 0 {
+			t.Fatalf("Encode(%d) = %q is not ordered after %q", n, word, previous)
+		}
+		previous = word
+
+		decoded, err := encoder.Decode(word)
+		if err != nil {
+			t.Fatalf("Decode(%q) error = %v", word, err)
+		}
+		if decoded != int(n) {
+			t.Fatalf("Decode(%q) = %d, want %d", word, decoded, n)
+		}
+	}
+
+	if _, err := encoder.Encode(capacity); err == nil {
+		t.Errorf("Encode(%d) should exceed capacity", capacity)
+	}
+}
+
+// alphabetKey maps a word to a string comparing by alphabet index per position.
+func alphabetKey(word string) string {
+	alphabets := []string{"aeiou", "bkpstxz", "bkpstxz", "aeiou", "bkpstxz"}
+	key := make([]byte, 0, len(alphabets))
+	for i, r := range []rune(word) {
+		key = append(key, byte('a'+strings.IndexRune(alphabets[i], r)))
+	}
+	return string(key)
+}
+
+func TestPhonotactics_DecodeRejectsForbiddenWords(t *testing.T) {
+	encoder, err := NewPhoneticEncoder(phonotacticConfig())
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	for _, word := range []string{"abbab", "astux", "aztab"} {
+		if _, err := encoder.Decode(word); err == nil {
+			t.Errorf("Decode(%q) should fail", word)
+		}
+	}
+}
+
+func TestPhonotactics_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules PhonotacticRules
+	}{
+		{"pair too short", PhonotacticRules{ForbiddenPairs: []string{"x"}}},
+		{"pair too long", PhonotacticRules{ForbiddenPairs: []string{"xqz"}}},
+		{"cluster key too long", PhonotacticRules{AllowedClusters: map[string][]string{"CCV": {"st"}}}},
+		{"cluster key unknown placeholder", PhonotacticRules{AllowedClusters: map[string][]string{"CL": {"st"}}}},
+		{"cluster character outside placeholder", PhonotacticRules{AllowedClusters: map[string][]string{"CV": {"sa", "sy"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := phonotacticConfig()
+			config.Phonotactics = tt.rules
+			if err := config.Validate(); err == nil {
+				t.Error("Validate() should fail")
+			}
+		})
+	}
+}
+
+func TestPhonotactics_NoPermittedWords(t *testing.T) {
+	config := phonotacticConfig()
+	config.Phonotactics = PhonotacticRules{AllowedClusters: map[string][]string{"CC": {}}}
+
+	if _, err := NewPhoneticEncoder(config); err == nil {
+		t.Error("NewPhoneticEncoder() should fail when a pattern admits no words")
+	}
+}
+
+func TestParsePhonidRCPhonotactics(t *testing.T) {
+	content := `
+[phonetic]
+patterns = ["VCCVC"]
+forbidden_pairs = ["zb", "ux"]
+
+[phonetic.placeholders]
+C = "bkpstxz"
+V = "aeiou"
+
+[phonetic.clusters]
+CC = ["st", "sp", "sk", "ks", "ts", "pt"]
+
+[[preflight]]
+input = 0
+output = "aksab"
+`
+	config, preflight, err := ParsePhonidRC(content)
+	if err != nil {
+		t.Fatalf("ParsePhonidRC() error = %v", err)
+	}
+
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+	if err := encoder.ValidatePreflight(preflight); err != nil {
+		t.Errorf("ValidatePreflight() error = %v", err)
+	}
+}
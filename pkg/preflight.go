@@ -5,9 +5,21 @@ import (
 	"fmt"
 )
 
+// WordCodec converts between numbers and words. PhoneticEncoder, Codec,
+// KeyRing and MultiDecoder implement it.
+type WordCodec interface {
+	Encode(number PositiveInt) (string, error)
+	Decode(word string) (int, error)
+}
+
 // ValidatePreflight checks if preflight tests pass for this encoder
 // Performs bidirectional validation: encoding (int->string) and decoding (string->int).
 func (p *PhoneticEncoder) ValidatePreflight(checks []PreflightCheck) error {
+	return validatePreflight(p, checks)
+}
+
+// validatePreflight runs preflight checks against any word codec.
+func validatePreflight(p WordCodec, checks []PreflightCheck) error {
 	if len(checks) == 0 {
 		return errors.New("at least one preflight check is required")
 	}
@@ -0,0 +1,194 @@
+package phonid
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+type (
+	// presetDefinition bundles a curated config with its pinned preflight vectors.
+	presetDefinition struct {
+		description string
+		config      PhonidConfig
+		preflight   []PreflightCheck
+	}
+)
+
+// presets is the registry of built-in configurations, keyed by name.
+// Preflight vectors pin each preset's encoding; changing them is a breaking change.
+var presets = map[string]presetDefinition{
+	"proquint": {
+		description: "Proquint-compatible 32-bit identifiers (CVCVC-CVCVC)",
+		config:      ProQuintConfig,
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "babab-babab"},
+			{Input: 0x7F000001, Output: "lusab-babad"},
+			{Input: 0xFFFFFFFF, Output: "zuzuz-zuzuz"},
+		},
+	},
+	"ascii": {
+		description: "Plain lowercase ASCII letters",
+		config: PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC", "CVCVCVC", "CVCVCVCVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bcdfghjklmnpqrstvwxz"),
+				Vowel:     RuneSet("aeiou"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "bab"},
+			{Input: 1_999, Output: "zuz"},
+			{Input: 2_000, Output: "bebab"},
+			{Input: 1_000_000, Output: "cababab"},
+			{Input: 199_999_999_999, Output: "zuzuzuzuzuz"},
+		},
+	},
+	"no-confusables": {
+		description: "Avoids letters that are easily misread or misheard (c, q, x, w, l, y, i, o)",
+		config: PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC", "CVCVCVC", "CVCVCVCVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdfghjkmnprstvz"),
+				Vowel:     RuneSet("aeu"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "bab"},
+			{Input: 674, Output: "zuz"},
+			{Input: 675, Output: "bebab"},
+			{Input: 1_000_000, Output: "ruvumar"},
+			{Input: 2_767_921_874, Output: "zuzuzuzuzuz"},
+		},
+	},
+	"minion": {
+		description: "Bouncy, vowel-heavy words (banana-like)",
+		config: PhonidConfig{
+			Patterns: []string{"CVV", "CVCVV", "CVCVCVV", "CVCVCVCVCVV"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdgkmnpt"),
+				Vowel:     RuneSet("aeiou"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "baa"},
+			{Input: 199, Output: "tuu"},
+			{Input: 200, Output: "bebaa"},
+			{Input: 1_000_000, Output: "babodababaa"},
+			{Input: 511_999_999, Output: "tututututuu"},
+		},
+	},
+	"elvish": {
+		description: "Flowing words built from liquids and soft consonants",
+		config: PhonidConfig{
+			Patterns: []string{"CVL", "CVLVC", "CVLVCVL", "CVLVCVLVCVL"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("cdfghstv"),
+				Liquid:    RuneSet("lmnr"),
+				Vowel:     RuneSet("aeiouy"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "cal"},
+			{Input: 191, Output: "vyr"},
+			{Input: 192, Output: "celac"},
+			{Input: 1_000_000, Output: "caluhelaful"},
+			{Input: 254_803_967, Output: "vyryvyryvyr"},
+		},
+	},
+	"kana": {
+		description: "Japanese romaji-friendly words: open syllables without clusters",
+		config: PhonidConfig{
+			Patterns: []string{"VCV", "VCVCV", "VCVCVCV", "VCVCVCVCVCV"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("kstnhmrgzdbp"),
+				Vowel:     RuneSet("aiueo"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "aka"},
+			{Input: 299, Output: "opo"},
+			{Input: 300, Output: "asaka"},
+			{Input: 1_000_000, Output: "ogudiza"},
+			{Input: 3_887_999_999, Output: "opopopopopo"},
+		},
+	},
+	"german": {
+		description: "German-flavored words including umlauts",
+		config: PhonidConfig{
+			Patterns: []string{"CVC", "CVCVC", "CVCVCVC", "CVCVCVCVCVC"},
+			Placeholders: PlaceholderMap{
+				Consonant: RuneSet("bdfghkmnprstwz"),
+				Vowel:     RuneSet("aeiouäöü"),
+			},
+		},
+		preflight: []PreflightCheck{
+			{Input: 0, Output: "bab"},
+			{Input: 1_567, Output: "züz"},
+			{Input: 1_568, Output: "bebab"},
+			{Input: 1_000_000, Output: "bäräsup"},
+			{Input: 246_727_835_647, Output: "züzüzüzüzüz"},
+		},
+	},
+}
+
+// Preset returns a copy of a built-in configuration and its pinned preflight checks.
+func Preset(name string) (*PhonidConfig, []PreflightCheck, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown preset %q (available: %v)", name, PresetNames())
+	}
+
+	return clonePhonidConfig(&preset.config), slices.Clone(preset.preflight), nil
+}
+
+// PresetNames returns the names of all built-in presets in sorted order.
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// PresetDescription returns a short human-readable description of a preset.
+func PresetDescription(name string) string {
+	return presets[name].description
+}
+
+// clonePhonidConfig deep-copies a config so callers cannot mutate shared state.
+func clonePhonidConfig(pc *PhonidConfig) *PhonidConfig {
+	placeholders := make(PlaceholderMap, len(pc.Placeholders))
+	for key, chars := range pc.Placeholders {
+		placeholders[key] = slices.Clone(chars)
+	}
+
+	var syllables SyllableMap
+	if pc.Syllables != nil {
+		syllables = make(SyllableMap, len(pc.Syllables))
+		for key, members := range pc.Syllables {
+			syllables[key] = slices.Clone(members)
+		}
+	}
+
+	var clusters map[string][]string
+	if pc.Phonotactics.AllowedClusters != nil {
+		clusters = make(map[string][]string, len(pc.Phonotactics.AllowedClusters))
+		for key, values := range pc.Phonotactics.AllowedClusters {
+			clusters[key] = slices.Clone(values)
+		}
+	}
+
+	var permutation *PermutationConfig
+	if pc.Permutation != nil {
+		p := *pc.Permutation
+		permutation = &p
+	}
+
+	return &PhonidConfig{
+		Patterns:     slices.Clone(pc.Patterns),
+		Placeholders: placeholders,
+		Syllables:    syllables,
+		Phonotactics: PhonotacticRules{
+			ForbiddenPairs:  slices.Clone(pc.Phonotactics.ForbiddenPairs),
+			AllowedClusters: clusters,
+		},
+		Permutation: permutation,
+	}
+}
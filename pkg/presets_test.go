@@ -0,0 +1,134 @@
+package phonid_test
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestPreset_PinnedVectors(t *testing.T) {
+	names := PresetNames()
+	for _, want := range []string{"proquint", "ascii", "no-confusables", "kana", "german", "elvish", "minion"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("PresetNames() = %v, missing %q", names, want)
+		}
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			config, preflight, err := Preset(name)
+			if err != nil {
+				t.Fatalf("Preset(%q) error = %v", name, err)
+			}
+			if PresetDescription(name) == "" {
+				t.Errorf("PresetDescription(%q) is empty", name)
+			}
+
+			encoder, err := NewPhoneticEncoder(config)
+			if err != nil {
+				t.Fatalf("NewPhoneticEncoder() error = %v", err)
+			}
+			if err := encoder.ValidatePreflight(preflight); err != nil {
+				t.Errorf("ValidatePreflight() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPreset_ReturnsCopy(t *testing.T) {
+	config, _, err := Preset("ascii")
+	if err != nil {
+		t.Fatalf("Preset() error = %v", err)
+	}
+	config.Patterns[0] = "VVV"
+	config.Placeholders[Vowel][0] = 'y'
+
+	again, _, err := Preset("ascii")
+	if err != nil {
+		t.Fatalf("Preset() error = %v", err)
+	}
+	if again.Patterns[0] != "CVC" || again.Placeholders[Vowel][0] != 'a' {
+		t.Error("mutating a preset must not affect the registry")
+	}
+}
+
+func TestPreset_Unknown(t *testing.T) {
+	if _, _, err := Preset("klingon"); err == nil {
+		t.Error("Preset(\"klingon\") should fail")
+	}
+}
+
+func TestParsePhonidRCPreset(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantPatterns []string
+		wantErr      bool
+	}{
+		{
+			name: "preset only",
+			content: `
+[phonetic]
+preset = "kana"
+
+[[preflight]]
+input = 0
+output = "aka"
+`,
+			wantPatterns: []string{"VCV", "VCVCV", "VCVCVCV", "VCVCVCVCVCV"},
+		},
+		{
+			name: "patterns override preset",
+			content: `
+[phonetic]
+preset = "kana"
+patterns = ["VCVCV"]
+
+[[preflight]]
+input = 0
+output = "akaka"
+`,
+			wantPatterns: []string{"VCVCV"},
+		},
+		{
+			name: "unknown preset",
+			content: `
+[phonetic]
+preset = "klingon"
+
+[[preflight]]
+input = 0
+output = "aka"
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, preflight, err := ParsePhonidRC(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePhonidRC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(config.Patterns, tt.wantPatterns) {
+				t.Errorf("Patterns = %v, want %v", config.Patterns, tt.wantPatterns)
+			}
+			if string(config.Placeholders[Consonant]) != "kstnhmrgzdbp" {
+				t.Errorf("Consonant = %q, want preset placeholders", string(config.Placeholders[Consonant]))
+			}
+
+			encoder, err := NewPhoneticEncoder(config)
+			if err != nil {
+				t.Fatalf("NewPhoneticEncoder() error = %v", err)
+			}
+			if err := encoder.ValidatePreflight(preflight); err != nil {
+				t.Errorf("ValidatePreflight() error = %v", err)
+			}
+		})
+	}
+}
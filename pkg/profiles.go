@@ -0,0 +1,167 @@
+package phonid
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	// profileTables are the tables a profile merges over its base.
+	profileTables = []string{"shuffle", "phonetic"}
+	// profileOwnKeys pin one resolved config and are never inherited.
+	profileOwnKeys = []string{"preflight", "expected_fingerprint"}
+)
+
+// LoadProfile loads the named profile from an rc file and returns a codec
+// that has passed the profile's preflight checks. An empty name selects the
+// top-level configuration.
+func LoadProfile(fp, name string) (*Codec, error) {
+	data, err := readConfigFile(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	config, preflight, err := parseProfile(string(data), name, FormatFromFilename(fp), filepath.Dir(fp))
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := NewCodec(config)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	if err := codec.ValidatePreflight(preflight); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return codec, nil
+}
+
+// ParseProfile resolves the named profile into a validated Config.
+// Tables merge key by key: nested tables are merged recursively, while
+// scalars and arrays in the profile replace inherited values. The profile
+// must define its own [[profiles.<name>.preflight]] checks.
+func ParseProfile(content, name string) (*Config, []PreflightCheck, error) {
+	return ParseProfileAs(content, name, FormatTOML)
+}
+
+// ParseProfileAs is ParseProfile for content of the given format.
+func ParseProfileAs(content, name string, format Format) (*Config, []PreflightCheck, error) {
+	return parseProfile(content, name, format, "")
+}
+
+// parseProfile resolves the named profile, resolving relative seed file
+// references against dir.
+func parseProfile(content, name string, format Format, dir string) (*Config, []PreflightCheck, error) {
+	if name == "" {
+		return parseConfigRC(content, format, dir)
+	}
+
+	// Reject unknown fields anywhere in the document before merging
+	tomlConfig, _, err := decodePhonidRC(content, format, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := tomlConfig.Profiles[name]; !ok {
+		return nil, nil, fmt.Errorf("unknown profile %q (available: %v)",
+			name, slices.Sorted(maps.Keys(tomlConfig.Profiles)))
+	}
+
+	// The document was decoded strictly above, so the format is known
+	codec, _ := format.codec()
+	var document map[string]any
+	if err := codec.decodeLoose([]byte(content), &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s config: %w", strings.ToUpper(string(format)), err)
+	}
+
+	resolved, err := resolveProfile(document, name, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := codec.marshal(resolved)
+	if err != nil {
+		return nil, nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+
+	config, preflight, err := parseConfigRC(string(data), format, dir)
+	if err != nil {
+		return nil, preflight, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return config, preflight, nil
+}
+
+// resolveProfile returns the document a profile describes, following Extends.
+func resolveProfile(document map[string]any, name string, chain []string) (map[string]any, error) {
+	if slices.Contains(chain, name) {
+		return nil, fmt.Errorf("profile inheritance cycle: %v", append(chain, name))
+	}
+	chain = append(chain, name)
+
+	profiles, _ := document["profiles"].(map[string]any)
+	profile, ok := profiles[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+
+	var base map[string]any
+	if extends, _ := profile["extends"].(string); extends != "" {
+		resolved, err := resolveProfile(document, extends, chain)
+		if err != nil {
+			return nil, err
+		}
+		base = resolved
+	} else {
+		base = maps.Clone(document)
+		delete(base, "profiles")
+	}
+
+	merged := maps.Clone(base)
+	for _, key := range profileOwnKeys {
+		delete(merged, key)
+	}
+	for _, table := range profileTables {
+		overrides, ok := profile[table].(map[string]any)
+		if !ok {
+			continue
+		}
+		inherited, _ := merged[table].(map[string]any)
+		merged[table] = mergeTables(inherited, overrides)
+	}
+	for _, key := range profileOwnKeys {
+		if value, ok := profile[key]; ok {
+			merged[key] = value
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeTables returns base with overrides applied recursively.
+func mergeTables(base, overrides map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]any, len(overrides))
+	}
+
+	for key, value := range overrides {
+		nested, isTable := value.(map[string]any)
+		inherited, inheritedTable := merged[key].(map[string]any)
+		if isTable && inheritedTable && !isSeedReference(nested) {
+			merged[key] = mergeTables(inherited, nested)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// isSeedReference reports whether a table is an { env } or { file } seed
+// reference, which replaces rather than merges.
+func isSeedReference(table map[string]any) bool {
+	_, env := table["env"]
+	_, file := table["file"]
+	return env || file
+}
@@ -0,0 +1,149 @@
+package phonid_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+const profilesConfig = `
+[phonetic]
+preset = "proquint"
+
+[[preflight]]
+input = 1337
+output = "babab-bihun"
+
+[profiles.orders.shuffle]
+rounds = 4
+seed = 12345
+
+[[profiles.orders.preflight]]
+input = 1337
+output = "jumuz-jozut"
+
+[profiles.tickets.phonetic]
+patterns = ["CVC", "CVCVC"]
+
+[profiles.tickets.phonetic.placeholders]
+C = "bdkst"
+V = "aeiou"
+
+[[profiles.tickets.preflight]]
+input = 42
+output = "dok"
+
+[profiles.customers]
+extends = "orders"
+
+[profiles.customers.shuffle]
+seed = 54321
+
+[[profiles.customers.preflight]]
+input = 1337
+output = "mozip-dojuf"
+`
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		profile  string
+		rounds   int
+		seed     uint64
+		patterns int
+	}{
+		{"", 0, 0, 1},
+		{"orders", 4, 12345, 1},
+		{"tickets", 0, 0, 2},
+		{"customers", 4, 54321, 1}, // Rounds inherited from orders
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			config, preflight, err := ParseProfile(profilesConfig, tt.profile)
+			if err != nil {
+				t.Fatalf("ParseProfile() error = %v", err)
+			}
+			if config.Shuffle.Rounds != tt.rounds || config.Shuffle.Seed != tt.seed {
+				t.Errorf("Shuffle = %+v, want rounds %d, seed %d", config.Shuffle, tt.rounds, tt.seed)
+			}
+			if len(config.Phonetic.Patterns) != tt.patterns {
+				t.Errorf("Patterns = %v, want %d patterns", config.Phonetic.Patterns, tt.patterns)
+			}
+
+			codec, err := NewCodec(config)
+			if err != nil {
+				t.Fatalf("NewCodec() error = %v", err)
+			}
+			if err := codec.ValidatePreflight(preflight); err != nil {
+				t.Errorf("ValidatePreflight() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseProfileInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+	}{
+		{"unknown profile", profilesConfig, "invoices"},
+		{
+			"cycle",
+			"[profiles.a]\nextends = \"b\"\n[profiles.b]\nextends = \"a\"\n",
+			"a",
+		},
+		{
+			"missing preflight",
+			"[phonetic]\npreset = \"proquint\"\n[[preflight]]\ninput = 0\noutput = \"babab-babab\"\n" +
+				"[profiles.orders.shuffle]\nrounds = 4\n",
+			"orders",
+		},
+		{
+			"unknown field",
+			"[profiles.orders.shuffle]\nround = 4\n[[profiles.orders.preflight]]\ninput = 0\noutput = \"babab\"\n",
+			"orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseProfile(tt.content, tt.profile); err == nil {
+				t.Error("ParseProfile() should fail")
+			}
+		})
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RcFileName)
+	if err := os.WriteFile(path, []byte(profilesConfig), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	codec, err := LoadProfile(path, "customers")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	word, err := codec.Encode(1337)
+	if err != nil || word != "mozip-dojuf" {
+		t.Errorf("Encode(1337) = %q, %v, want \"mozip-dojuf\"", word, err)
+	}
+	number, err := codec.Decode(word)
+	if err != nil || number != 1337 {
+		t.Errorf("Decode(%q) = %d, %v, want 1337", word, number, err)
+	}
+
+	// A preflight mismatch makes the profile unusable
+	broken := strings.Replace(profilesConfig, "mozip-dojuf", "mozip-dojuv", 1)
+	if err := os.WriteFile(path, []byte(broken), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := LoadProfile(path, "customers"); err == nil {
+		t.Error("LoadProfile() should fail on preflight mismatch")
+	}
+}
@@ -0,0 +1,105 @@
+package phonid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatTOML is the default rc file format.
+	FormatTOML Format = "toml"
+	// FormatYAML reads rc files with the TOMLConfig schema written as YAML.
+	FormatYAML Format = "yaml"
+	// FormatJSON reads rc files with the TOMLConfig schema written as JSON.
+	FormatJSON Format = "json"
+)
+
+type (
+	// Format is an rc file syntax. All formats share the TOMLConfig schema,
+	// reject unknown fields and require preflight checks.
+	Format string
+
+	// formatCodec decodes and encodes one rc file syntax.
+	formatCodec struct {
+		// decodeStrict decodes into a struct, rejecting unknown fields
+		decodeStrict func(content []byte, v any) error
+		// decodeLoose decodes into generic maps, preserving integer precision
+		decodeLoose func(content []byte, v any) error
+		marshal     func(v any) ([]byte, error)
+	}
+)
+
+var formatCodecs = map[Format]formatCodec{
+	FormatTOML: {
+		decodeStrict: func(content []byte, v any) error {
+			decoder := toml.NewDecoder(bytes.NewReader(content))
+			decoder.DisallowUnknownFields() // Strict mode - reject unknown fields
+			return decoder.Decode(v)
+		},
+		decodeLoose: toml.Unmarshal,
+		marshal:     toml.Marshal,
+	},
+	FormatYAML: {
+		decodeStrict: func(content []byte, v any) error {
+			decoder := yaml.NewDecoder(bytes.NewReader(content))
+			decoder.KnownFields(true)
+			return ignoreEOF(decoder.Decode(v))
+		},
+		decodeLoose: func(content []byte, v any) error {
+			return ignoreEOF(yaml.NewDecoder(bytes.NewReader(content)).Decode(v))
+		},
+		marshal: yaml.Marshal,
+	},
+	FormatJSON: {
+		decodeStrict: func(content []byte, v any) error {
+			decoder := json.NewDecoder(bytes.NewReader(content))
+			decoder.DisallowUnknownFields()
+			decoder.UseNumber() // Keep 64-bit seeds exact
+			return decoder.Decode(v)
+		},
+		decodeLoose: func(content []byte, v any) error {
+			decoder := json.NewDecoder(bytes.NewReader(content))
+			decoder.UseNumber()
+			return decoder.Decode(v)
+		},
+		marshal: json.Marshal,
+	},
+}
+
+// codec returns the decoder set for the format.
+func (f Format) codec() (formatCodec, error) {
+	codec, ok := formatCodecs[f]
+	if !ok {
+		return formatCodec{}, fmt.Errorf("unknown rc format %q (allowed: %s, %s, %s)",
+			f, FormatTOML, FormatYAML, FormatJSON)
+	}
+	return codec, nil
+}
+
+// FormatFromFilename returns the format implied by an rc file name:
+// .yaml and .json select YAML and JSON, anything else is TOML.
+func FormatFromFilename(filename string) Format {
+	switch {
+	case strings.HasSuffix(filename, RcFileYAMLSuffix):
+		return FormatYAML
+	case strings.HasSuffix(filename, RcFileJSONSuffix):
+		return FormatJSON
+	default:
+		return FormatTOML
+	}
+}
+
+// ignoreEOF treats an empty YAML document as an empty config.
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package phonid_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+var equivalentConfigs = map[Format]string{
+	// TOML integers are signed, so the maximum seed is written in hex
+	FormatTOML: `
+[shuffle]
+rounds = 4
+seed = "0xffffffffffffffff"
+
+[phonetic]
+preset = "proquint"
+
+[phonetic.permutation]
+seed = 12345
+
+[[preflight]]
+input = 1337
+output = "vovov-vuniz"
+`,
+	FormatYAML: `
+shuffle:
+  rounds: 4
+  seed: 18446744073709551615
+phonetic:
+  preset: proquint
+  permutation:
+    seed: 12345
+preflight:
+  - input: 1337
+    output: vovov-vuniz
+`,
+	FormatJSON: `{
+  "shuffle": {"rounds": 4, "seed": 18446744073709551615},
+  "phonetic": {"preset": "proquint", "permutation": {"seed": 12345}},
+  "preflight": [{"input": 1337, "output": "vovov-vuniz"}]
+}`,
+}
+
+func TestParseConfigRCAsFormats(t *testing.T) {
+	want, wantPreflight, err := ParseConfigRCAs(equivalentConfigs[FormatTOML], FormatTOML)
+	if err != nil {
+		t.Fatalf("ParseConfigRCAs(toml) error = %v", err)
+	}
+
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			got, preflight, err := ParseConfigRCAs(equivalentConfigs[format], format)
+			if err != nil {
+				t.Fatalf("ParseConfigRCAs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseConfigRCAs() = %+v, want %+v", got.Shuffle, want.Shuffle)
+			}
+			if !reflect.DeepEqual(preflight, wantPreflight) {
+				t.Errorf("preflight = %v, want %v", preflight, wantPreflight)
+			}
+		})
+	}
+}
+
+func TestParsePhonidRCAsStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		content string
+	}{
+		{"yaml unknown field", FormatYAML, "phonetic:\n  preset: proquint\n  pattern: [CVC]\npreflight:\n  - input: 0\n    output: babab\n"},
+		{"json unknown field", FormatJSON, `{"phonetic": {"presets": "proquint"}, "preflight": [{"input": 0, "output": "babab"}]}`},
+		{"yaml missing preflight", FormatYAML, "phonetic:\n  preset: proquint\n"},
+		{"json missing preflight", FormatJSON, `{"phonetic": {"preset": "proquint"}}`},
+		{"empty yaml", FormatYAML, ""},
+		{"unknown format", Format("ini"), "preset = proquint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParsePhonidRCAs(tt.content, tt.format); err == nil {
+				t.Error("ParsePhonidRCAs() should fail")
+			}
+		})
+	}
+}
+
+func TestLoadPhonidRCByExtension(t *testing.T) {
+	dir := t.TempDir()
+
+	for format, name := range map[Format]string{
+		FormatYAML: ".orders.phonidrc.yaml",
+		FormatJSON: ".phonidrc.json",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(equivalentConfigs[format]), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if got := FormatFromFilename(name); got != format {
+			t.Errorf("FormatFromFilename(%q) = %q, want %q", name, got, format)
+		}
+
+		config, preflight, err := LoadPhonidRC(path)
+		if err != nil {
+			t.Fatalf("LoadPhonidRC(%s) error = %v", name, err)
+		}
+		encoder, err := NewPhoneticEncoder(config)
+		if err != nil {
+			t.Fatalf("NewPhoneticEncoder() error = %v", err)
+		}
+		if err := encoder.ValidatePreflight(preflight); err != nil {
+			t.Errorf("%s: ValidatePreflight() error = %v", name, err)
+		}
+	}
+}
+
+func TestParseProfileAsYAML(t *testing.T) {
+	content := `
+phonetic:
+  preset: proquint
+profiles:
+  orders:
+    shuffle:
+      rounds: 4
+      seed: 12345
+    preflight:
+      - input: 1337
+        output: jumuz-jozut
+`
+	config, preflight, err := ParseProfileAs(content, "orders", FormatYAML)
+	if err != nil {
+		t.Fatalf("ParseProfileAs() error = %v", err)
+	}
+
+	codec, err := NewCodec(config)
+	if err != nil {
+		t.Fatalf("NewCodec() error = %v", err)
+	}
+	if err := codec.ValidatePreflight(preflight); err != nil {
+		t.Errorf("ValidatePreflight() error = %v", err)
+	}
+}
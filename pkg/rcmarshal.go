@@ -0,0 +1,174 @@
+package phonid
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// rcHeader opens every file written by MarshalPhonidRC.
+const rcHeader = `# phonid configuration.
+# Every value below takes part in generating IDs: changing any of them
+# changes published IDs. The preflight checks catch accidental edits.
+`
+
+// MarshalPhonidRC writes config and checks as canonical, commented TOML.
+// The output is fully explicit (no preset, no defaults) and deterministic:
+// parsing it with ParseConfigRC and marshalling the result again yields the
+// same bytes. Seeds are written as 16-digit hex strings to keep the full
+// uint64 range. Keyed round functions are rejected, since their key must not
+// be written to a file.
+func MarshalPhonidRC(config *Config, checks []PreflightCheck) ([]byte, error) {
+	if config == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if rf := config.Shuffle.RoundFunction; rf != "" && rf != RoundFunctionFNV {
+		return nil, fmt.Errorf("round function %q cannot be written to an rc file; supply its key at runtime", rf)
+	}
+	if len(checks) == 0 {
+		return nil, errors.New("at least one preflight check is required")
+	}
+	for i, check := range checks {
+		if err := check.Input.Validate(); err != nil {
+			return nil, fmt.Errorf("preflight check %d: %w", i, err)
+		}
+	}
+
+	fingerprint, err := config.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(rcHeader)
+	b.WriteString("\n# Encoding algorithm; pinned so library upgrades keep producing these IDs\n")
+	fmt.Fprintf(&b, "encoding_version = %d\n", config.EncodingVersion)
+	b.WriteString("# Asserted on load; changes whenever any value in this file changes\n")
+	fmt.Fprintf(&b, "expected_fingerprint = %s\n", tomlString(fingerprint))
+	writeShuffleTable(&b, config)
+	writePhoneticTable(&b, config.Phonetic)
+
+	b.WriteString("\n# Verified on load: input must encode to output\n")
+	for i, check := range checks {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[[preflight]]\ninput = %d\noutput = %s\n", check.Input, tomlString(check.Output))
+	}
+
+	return []byte(b.String()), nil
+}
+
+// writeShuffleTable writes the [shuffle] table.
+func writeShuffleTable(b *strings.Builder, config *Config) {
+	b.WriteString("\n[shuffle]\n")
+	b.WriteString("# Asserted on load; a mismatch means the phonetic capacity changed\n")
+	fmt.Fprintf(b, "bit_width = %d\n", config.Shuffle.BitWidth)
+	b.WriteString("# Feistel rounds; 0 keeps numbers in order\n")
+	fmt.Fprintf(b, "rounds = %d\n", config.Shuffle.Rounds)
+	fmt.Fprintf(b, "seed = %s\n", hexSeed(config.Shuffle.Seed))
+}
+
+// writePhoneticTable writes the [phonetic] table and its sub-tables.
+func writePhoneticTable(b *strings.Builder, phonetic *PhonidConfig) {
+	b.WriteString("\n[phonetic]\n")
+	b.WriteString("# Placeholder letters per word shape, see [phonetic.placeholders]\n")
+	fmt.Fprintf(b, "patterns = %s\n", tomlStringArray(phonetic.Patterns))
+	if pairs := phonetic.Phonotactics.ForbiddenPairs; len(pairs) > 0 {
+		b.WriteString("# Adjacent characters that never occur in a word\n")
+		fmt.Fprintf(b, "forbidden_pairs = %s\n", tomlStringArray(slices.Sorted(slices.Values(pairs))))
+	}
+
+	if len(phonetic.Placeholders) > 0 {
+		b.WriteString("\n# Symbol order matters: it is the digit order of each position\n")
+		b.WriteString("[phonetic.placeholders]\n")
+		for _, key := range slices.Sorted(maps.Keys(phonetic.Placeholders)) {
+			fmt.Fprintf(b, "%s = %s\n", tomlKey(string(key)), tomlString(string(phonetic.Placeholders[key])))
+		}
+	}
+
+	if len(phonetic.Syllables) > 0 {
+		b.WriteString("\n[phonetic.syllables]\n")
+		for _, key := range slices.Sorted(maps.Keys(phonetic.Syllables)) {
+			fmt.Fprintf(b, "%s = %s\n", tomlKey(string(key)), tomlStringArray(phonetic.Syllables[key]))
+		}
+	}
+
+	if clusters := phonetic.Phonotactics.AllowedClusters; len(clusters) > 0 {
+		b.WriteString("\n# The only clusters allowed where these placeholders meet\n")
+		b.WriteString("[phonetic.clusters]\n")
+		for _, key := range slices.Sorted(maps.Keys(clusters)) {
+			fmt.Fprintf(b, "%s = %s\n", tomlKey(key), tomlStringArray(slices.Sorted(slices.Values(clusters[key]))))
+		}
+	}
+
+	if permutation := phonetic.Permutation; permutation != nil {
+		b.WriteString("\n[phonetic.permutation]\n")
+		fmt.Fprintf(b, "seed = %s\n", hexSeed(permutation.Seed))
+		fmt.Fprintf(b, "per_position = %t\n", permutation.PerPosition)
+	}
+}
+
+// hexSeed formats a seed as a TOML string accepted by ParseSeed.
+func hexSeed(seed uint64) string {
+	return fmt.Sprintf("%q", fmt.Sprintf("%s%016x", seedHexPrefix, seed))
+}
+
+// tomlStringArray formats values as a single-line TOML array of strings.
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = tomlString(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// tomlKey formats key as a bare TOML key where possible and quotes it otherwise.
+func tomlKey(key string) string {
+	bare := key != "" && !strings.ContainsFunc(key, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	})
+	if bare {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlString formats s as a TOML basic string. Printable characters are kept
+// verbatim, so non-Latin alphabets stay readable.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f || !strconv.IsPrint(r) {
+				fmt.Fprintf(&b, `\U%08X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
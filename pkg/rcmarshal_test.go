@@ -0,0 +1,166 @@
+package phonid_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestMarshalPhonidRC_RoundTrip(t *testing.T) {
+	proquint, _, err := Preset("proquint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kana, _, err := Preset("kana")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts []ConfigOption
+	}{
+		{"defaults", nil},
+		{"proquint shuffled", []ConfigOption{
+			WithPhonetic(proquint),
+			WithRounds(4),
+			WithSeed(1<<63 + 12345),
+		}},
+		{"kana", []ConfigOption{WithPhonetic(kana)}},
+		{"phonotactics and permutation", []ConfigOption{
+			WithPhonetic(&PhonidConfig{
+				Patterns: []string{"VCCVC"},
+				Placeholders: PlaceholderMap{
+					Vowel:     RuneSet("aeiou"),
+					Consonant: RuneSet("bkpstxz"),
+				},
+				Phonotactics: PhonotacticRules{
+					ForbiddenPairs:  []string{"zb", "ux"},
+					AllowedClusters: map[string][]string{"CC": {"st", "sp", "sk", "ks", "ts", "pt"}},
+				},
+				Permutation: &PermutationConfig{Seed: 7, PerPosition: true},
+			}),
+		}},
+		{"syllables", []ConfigOption{
+			WithPhonetic(&PhonidConfig{
+				Patterns:     []string{"CVC"},
+				Placeholders: PlaceholderMap{Vowel: RuneSet("aeiou")},
+				Syllables:    SyllableMap{Consonant: {"sh", "th", "k", "m"}},
+			}),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := NewConfigWithOptions(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			codec, err := NewCodec(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			word, err := codec.Encode(42)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checks := []PreflightCheck{{Input: 42, Output: word}}
+
+			first, err := MarshalPhonidRC(config, checks)
+			if err != nil {
+				t.Fatalf("MarshalPhonidRC() error = %v", err)
+			}
+
+			parsed, parsedChecks, err := ParseConfigRC(string(first))
+			if err != nil {
+				t.Fatalf("ParseConfigRC() error = %v\n%s", err, first)
+			}
+			second, err := MarshalPhonidRC(parsed, parsedChecks)
+			if err != nil {
+				t.Fatalf("second MarshalPhonidRC() error = %v", err)
+			}
+			if string(first) != string(second) {
+				t.Fatalf("round trip changed the output:\n--- first\n%s\n--- second\n%s", first, second)
+			}
+
+			if _, _, err := ParsePhonidRC(string(first)); err != nil {
+				t.Fatalf("ParsePhonidRC() error = %v", err)
+			}
+			parsedCodec, err := NewCodec(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := parsedCodec.ValidatePreflight(parsedChecks); err != nil {
+				t.Errorf("parsed config fails the preflight: %v", err)
+			}
+		})
+	}
+}
+
+func TestMarshalPhonidRC_Format(t *testing.T) {
+	config, err := NewConfigWithOptions(
+		WithPhonetic(&PhonidConfig{
+			Patterns:     []string{"CVC"},
+			Placeholders: PlaceholderMap{Vowel: RuneSet("aeiou"), Consonant: RuneSet(`bdk"\`)},
+		}),
+		WithRounds(2),
+		WithSeed(255),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := MarshalPhonidRC(config, []PreflightCheck{{Input: 0, Output: "bab"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"\n[shuffle]\n",
+		"rounds = 2\n",
+		`seed = "0x00000000000000ff"` + "\n",
+		`patterns = ["CVC"]` + "\n",
+		"C = \"bdk\\\"\\\\\"\nV = \"aeiou\"\n",
+		"[[preflight]]\ninput = 0\noutput = \"bab\"\n",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("output lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestMarshalPhonidRC_Errors(t *testing.T) {
+	valid, err := NewConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyed, err := NewConfigWithOptions(
+		WithRoundFunction(RoundFunctionHMACSHA256),
+		WithRounds(MinKeyedRounds),
+		WithKey([]byte("0123456789abcdef")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []PreflightCheck{{Input: 0, Output: "babab"}}
+
+	tests := []struct {
+		name   string
+		config *Config
+		checks []PreflightCheck
+	}{
+		{"nil config", nil, checks},
+		{"no checks", valid, nil},
+		{"negative input", valid, []PreflightCheck{{Input: -1, Output: "babab"}}},
+		{"keyed", keyed, checks},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MarshalPhonidRC(tt.config, tt.checks); err == nil {
+				t.Error("MarshalPhonidRC() expected error")
+			}
+		})
+	}
+}
@@ -1,49 +1,86 @@
 package phonid
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/pelletier/go-toml/v2"
 )
 
 const (
-	RcFileName      = ".phonidrc"
-	RcFileOptSuffix = ".toml"
+	RcFileName       = ".phonidrc"
+	RcFileOptSuffix  = ".toml"
+	RcFileYAMLSuffix = ".yaml"
+	RcFileJSONSuffix = ".json"
 )
 
+// rcFileSuffixes are the optional rc file name suffixes, in order of precedence.
+var rcFileSuffixes = []string{"", RcFileOptSuffix, RcFileYAMLSuffix, RcFileJSONSuffix}
+
 type (
 	// PositiveInt represents a non-negative integer.
 	PositiveInt int
-	// TOMLConfig represents the top-level TOML structure.
+	// TOMLConfig represents the top-level rc file structure.
+	// YAML and JSON rc files use the same schema.
 	TOMLConfig struct {
-		Base      PositiveInt       `toml:"base,omitempty"`
-		Shuffle   TOMLShuffleConfig `toml:"shuffle,omitempty"`
-		Phonetic  TOMLPhonidConfig  `toml:"phonetic,omitempty"`
-		Preflight []PreflightCheck  `toml:"preflight"` // Required - no omitempty
+		Base PositiveInt `toml:"base,omitempty" yaml:"base,omitempty" json:"base,omitempty"`
+		// Refuse literal seeds; require env/file references
+		StrictSeeds bool `toml:"strict_seeds,omitempty" yaml:"strict_seeds,omitempty" json:"strict_seeds,omitempty"`
+		// Pins the encoding algorithm; omitted means DefaultEncodingVersion
+		EncodingVersion PositiveInt `toml:"encoding_version,omitempty" yaml:"encoding_version,omitempty" json:"encoding_version,omitempty"`
+		// Asserted against Config.Fingerprint; never inherited by profiles
+		ExpectedFingerprint string            `toml:"expected_fingerprint,omitempty" yaml:"expected_fingerprint,omitempty" json:"expected_fingerprint,omitempty"`
+		Shuffle             TOMLShuffleConfig `toml:"shuffle,omitempty" yaml:"shuffle,omitempty" json:"shuffle,omitempty"`
+		Phonetic            TOMLPhonidConfig  `toml:"phonetic,omitempty" yaml:"phonetic,omitempty" json:"phonetic,omitempty"`
+		Preflight           []PreflightCheck  `toml:"preflight" yaml:"preflight" json:"preflight"` // Required - no omitempty
+
+		Profiles map[string]TOMLProfile `toml:"profiles,omitempty" yaml:"profiles,omitempty" json:"profiles,omitempty"`
+	}
+
+	// TOMLProfile is a named codec inside an rc file. Its tables are merged
+	// over the profile named by Extends, or over the top-level tables when
+	// Extends is empty. Preflight checks and the expected fingerprint are
+	// never inherited.
+	TOMLProfile struct {
+		Extends             string            `toml:"extends,omitempty" yaml:"extends,omitempty" json:"extends,omitempty"`
+		ExpectedFingerprint string            `toml:"expected_fingerprint,omitempty" yaml:"expected_fingerprint,omitempty" json:"expected_fingerprint,omitempty"`
+		Shuffle             TOMLShuffleConfig `toml:"shuffle,omitempty" yaml:"shuffle,omitempty" json:"shuffle,omitempty"`
+		Phonetic            TOMLPhonidConfig  `toml:"phonetic,omitempty" yaml:"phonetic,omitempty" json:"phonetic,omitempty"`
+		Preflight           []PreflightCheck  `toml:"preflight" yaml:"preflight" json:"preflight"`
 	}
 
 	// PreflightCheck represents a single input->output verification.
 	PreflightCheck struct {
-		Input  PositiveInt `toml:"input"`
-		Output string      `toml:"output"`
+		Input  PositiveInt `toml:"input" yaml:"input" json:"input"`
+		Output string      `toml:"output" yaml:"output" json:"output"`
 	}
 
 	// TOMLShuffleConfig represents shuffle configuration.
+	// Seed is an integer, a string accepted by ParseSeed, or a table
+	// { env = "NAME" } / { file = "PATH" } resolved at load time.
 	TOMLShuffleConfig struct {
-		BitWidth PositiveInt `toml:"bit_width,omitempty"`
-		Rounds   PositiveInt `toml:"rounds,omitempty"`
-		Seed     PositiveInt `toml:"seed,omitempty"`
+		BitWidth PositiveInt `toml:"bit_width,omitempty" yaml:"bit_width,omitempty" json:"bit_width,omitempty"`
+		Rounds   PositiveInt `toml:"rounds,omitempty" yaml:"rounds,omitempty" json:"rounds,omitempty"`
+		Seed     any         `toml:"seed,omitempty" yaml:"seed,omitempty" json:"seed,omitempty"`
 	}
 
 	// TOMLPhonidConfig represents the phonetic configuration.
 	TOMLPhonidConfig struct {
-		Patterns     []string          `toml:"patterns,omitempty"`
-		Placeholders map[string]string `toml:"placeholders,omitempty"`
+		Preset         string              `toml:"preset,omitempty" yaml:"preset,omitempty" json:"preset,omitempty"`
+		Patterns       []string            `toml:"patterns,omitempty" yaml:"patterns,omitempty" json:"patterns,omitempty"`
+		Placeholders   map[string]string   `toml:"placeholders,omitempty" yaml:"placeholders,omitempty" json:"placeholders,omitempty"`
+		Syllables      map[string][]string `toml:"syllables,omitempty" yaml:"syllables,omitempty" json:"syllables,omitempty"`
+		ForbiddenPairs []string            `toml:"forbidden_pairs,omitempty" yaml:"forbidden_pairs,omitempty" json:"forbidden_pairs,omitempty"`
+		Clusters       map[string][]string `toml:"clusters,omitempty" yaml:"clusters,omitempty" json:"clusters,omitempty"`
+		Permutation    *TOMLPermutation    `toml:"permutation,omitempty" yaml:"permutation,omitempty" json:"permutation,omitempty"`
+	}
+
+	// TOMLPermutation represents the seeded alphabet permutation.
+	// Seed accepts the same forms as TOMLShuffleConfig.Seed.
+	TOMLPermutation struct {
+		Seed        any  `toml:"seed" yaml:"seed" json:"seed"`
+		PerPosition bool `toml:"per_position,omitempty" yaml:"per_position,omitempty" json:"per_position,omitempty"`
 	}
 )
 
@@ -55,13 +92,14 @@ func (p PositiveInt) Validate() error {
 }
 
 // LoadPhonidRC loads and validates a PhonidConfig from a phonidrc file with strict preflight validation.
+// The format (TOML, YAML or JSON) follows from the file name.
 func LoadPhonidRC(fp string) (*PhonidConfig, []PreflightCheck, error) {
 	data, err := readConfigFile(fp)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return ParsePhonidRC(string(data))
+	return parsePhonidRCInternal(string(data), FormatFromFilename(fp), false, filepath.Dir(fp))
 }
 
 // LoadPhonidRCLenient loads a PhonidConfig without requiring preflight checks
@@ -72,33 +110,133 @@ func LoadPhonidRCLenient(fp string) (*PhonidConfig, []PreflightCheck, error) {
 		return nil, nil, err
 	}
 
-	return ParsePhonidRCLenient(string(data))
+	return parsePhonidRCInternal(string(data), FormatFromFilename(fp), true, filepath.Dir(fp))
 }
 
 // ParsePhonidRC parses TOML content requiring preflight checks
 // Used exclusively by 'phonid preflight --suggest' command.
 func ParsePhonidRC(content string) (*PhonidConfig, []PreflightCheck, error) {
-	return parsePhonidRCInternal(content, false)
+	return parsePhonidRCInternal(content, FormatTOML, false, "")
+}
+
+// ParsePhonidRCAs parses content of the given format requiring preflight checks.
+func ParsePhonidRCAs(content string, format Format) (*PhonidConfig, []PreflightCheck, error) {
+	return parsePhonidRCInternal(content, format, false, "")
 }
 
 // ParsePhonidRCLenient parses TOML content without requiring preflight checks
 // Used exclusively by 'phonid preflight --suggest' command.
 func ParsePhonidRCLenient(content string) (*PhonidConfig, []PreflightCheck, error) {
-	return parsePhonidRCInternal(content, true)
+	return parsePhonidRCInternal(content, FormatTOML, true, "")
 }
 
-// ParsePhonidRC parses TOML content into a validated PhonidConfig using strict mode.
-func parsePhonidRCInternal(content string, lenitent bool) (*PhonidConfig, []PreflightCheck, error) {
-	var tomlConfig TOMLConfig
+// LoadConfigRC loads a complete Config, including the [shuffle] table, from a
+// phonidrc file with strict preflight validation. A bit_width and an
+// expected_fingerprint in the file are asserted against the calculated ones.
+// Relative { file = "PATH" } seed references resolve against the directory
+// of fp.
+func LoadConfigRC(fp string) (*Config, []PreflightCheck, error) {
+	data, err := readConfigFile(fp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parseConfigRC(string(data), FormatFromFilename(fp), filepath.Dir(fp))
+}
+
+// ParseConfigRC parses TOML content into a validated Config.
+func ParseConfigRC(content string) (*Config, []PreflightCheck, error) {
+	return ParseConfigRCAs(content, FormatTOML)
+}
+
+// ParseConfigRCAs parses content of the given format into a validated Config.
+// Relative { file = "PATH" } seed references resolve against the working
+// directory; use LoadConfigRC to resolve them against the rc file.
+func ParseConfigRCAs(content string, format Format) (*Config, []PreflightCheck, error) {
+	return parseConfigRC(content, format, "")
+}
+
+// parseConfigRC parses content into a validated Config, resolving relative
+// seed file references against dir.
+func parseConfigRC(content string, format Format, dir string) (*Config, []PreflightCheck, error) {
+	tomlConfig, preflight, err := decodePhonidRC(content, format, false)
+	if err != nil {
+		return nil, preflight, err
+	}
+
+	phonetic, err := tomlConfig.phonidConfig(dir)
+	if err != nil {
+		return nil, preflight, err
+	}
+
+	shuffle := tomlConfig.Shuffle
+	if err := shuffle.Rounds.Validate(); err != nil {
+		return nil, preflight, fmt.Errorf("invalid rounds: %w", err)
+	}
+	if err := shuffle.BitWidth.Validate(); err != nil {
+		return nil, preflight, fmt.Errorf("invalid bit_width: %w", err)
+	}
+	// Without rounds nothing is shuffled, so strict mode needs no seed
+	var seed uint64
+	if shuffle.Seed != nil || shuffle.Rounds > 0 {
+		seed, err = resolveSeed(shuffle.Seed, tomlConfig.StrictSeeds, dir)
+		if err != nil {
+			return nil, preflight, fmt.Errorf("invalid shuffle seed: %w", err)
+		}
+	}
+
+	config, err := NewConfigWithOptions(
+		WithPhonetic(phonetic),
+		WithRounds(int(shuffle.Rounds)),
+		WithSeed(seed),
+		WithExpectedBitWidth(int(shuffle.BitWidth)),
+		WithExpectedFingerprint(tomlConfig.ExpectedFingerprint),
+		WithEncodingVersion(int(tomlConfig.EncodingVersion)),
+	)
+	if err != nil {
+		return nil, preflight, err
+	}
+	return config, preflight, nil
+}
+
+// parsePhonidRCInternal parses TOML content into a PhonidConfig using strict mode.
+// Relative seed file references resolve against dir.
+func parsePhonidRCInternal(
+	content string, format Format, lenient bool, dir string,
+) (*PhonidConfig, []PreflightCheck, error) {
+	tomlConfig, preflight, err := decodePhonidRC(content, format, lenient)
+	if err != nil {
+		return nil, preflight, err
+	}
+
+	config, err := tomlConfig.phonidConfig(dir)
+	if err != nil {
+		return nil, preflight, err
+	}
 
-	// Create decoder with strict mode enabled
-	decoder := toml.NewDecoder(bytes.NewReader([]byte(content)))
-	decoder.DisallowUnknownFields() // Strict mode - reject unknown fields
+	// The shuffle seed is not resolved here, but strict mode still rejects literals
+	shuffle := tomlConfig.Shuffle
+	if tomlConfig.StrictSeeds && (shuffle.Seed != nil || shuffle.Rounds > 0) {
+		if err := checkStrictSeed(shuffle.Seed); err != nil {
+			return nil, preflight, fmt.Errorf("invalid shuffle seed: %w", err)
+		}
+	}
+	return config, preflight, nil
+}
+
+// decodePhonidRC decodes content in strict mode and checks the top-level fields.
+func decodePhonidRC(content string, format Format, lenitent bool) (*TOMLConfig, []PreflightCheck, error) {
+	var tomlConfig TOMLConfig
 	preflight := make([]PreflightCheck, 0)
 
-	if err := decoder.Decode(&tomlConfig); err != nil {
-		// pelletier/go-toml v2 provides contextualized error messages
-		return nil, preflight, fmt.Errorf("failed to parse TOML config: %w", err)
+	codec, err := format.codec()
+	if err != nil {
+		return nil, preflight, err
+	}
+
+	if err := codec.decodeStrict([]byte(content), &tomlConfig); err != nil {
+		// The decoders provide contextualized error messages
+		return nil, preflight, fmt.Errorf("failed to parse %s config: %w", strings.ToUpper(string(format)), err)
 	}
 
 	// Require at least one preflight check
@@ -116,10 +254,45 @@ func parsePhonidRCInternal(content string, lenitent bool) (*PhonidConfig, []Pref
 	if err := tomlConfig.Base.Validate(); err != nil {
 		return nil, preflight, fmt.Errorf("invalid base: %w", err)
 	}
+	if err := tomlConfig.EncodingVersion.Validate(); err != nil {
+		return nil, preflight, fmt.Errorf("invalid encoding_version: %w", err)
+	}
 
-	// Convert TOML structure to PhonidConfig
-	config := &PhonidConfig{
-		Patterns: tomlConfig.Phonetic.Patterns,
+	return &tomlConfig, preflight, nil
+}
+
+// phonidConfig converts the [phonetic] table to a PhonidConfig, starting from
+// a preset if one is named. Relative seed file references resolve against dir.
+func (tomlConfig *TOMLConfig) phonidConfig(dir string) (*PhonidConfig, error) {
+	config := &PhonidConfig{}
+	if name := tomlConfig.Phonetic.Preset; name != "" {
+		presetConfig, _, err := Preset(name)
+		if err != nil {
+			return nil, err
+		}
+		config = presetConfig
+	}
+
+	// Explicit keys override the preset
+	if tomlConfig.Phonetic.Patterns != nil {
+		config.Patterns = tomlConfig.Phonetic.Patterns
+	}
+	if tomlConfig.Phonetic.ForbiddenPairs != nil || tomlConfig.Phonetic.Clusters != nil {
+		config.Phonotactics = PhonotacticRules{
+			ForbiddenPairs:  tomlConfig.Phonetic.ForbiddenPairs,
+			AllowedClusters: tomlConfig.Phonetic.Clusters,
+		}
+	}
+
+	if permutation := tomlConfig.Phonetic.Permutation; permutation != nil {
+		seed, err := resolveSeed(permutation.Seed, tomlConfig.StrictSeeds, dir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid permutation seed: %w", err)
+		}
+		config.Permutation = &PermutationConfig{
+			Seed:        seed,
+			PerPosition: permutation.PerPosition,
+		}
 	}
 
 	// Convert string-based placeholders to PlaceholderType-based
@@ -127,34 +300,36 @@ func parsePhonidRCInternal(content string, lenitent bool) (*PhonidConfig, []Pref
 		config.Placeholders = make(map[PlaceholderType]RuneSet)
 
 		for keyStr, stringChars := range tomlConfig.Phonetic.Placeholders {
-			// Validate placeholder key - convert to runes first for proper UTF-8 handling
-			keyRunes := []rune(keyStr)
-			if len(keyRunes) != 1 {
-				return nil, preflight, fmt.Errorf(
-					"placeholder key '%s' must be single character",
-					keyStr,
-				)
-			}
-
-			placeholderType := PlaceholderType(keyRunes[0])
-
-			// Validate placeholder type is allowed
-			if _, isAllowed := AllowedPlaceholders[placeholderType]; !isAllowed {
-				return nil, preflight, fmt.Errorf(
-					"placeholder '%c' is not allowed. Valid placeholders: %v",
-					placeholderType,
-					getValidPlaceholderKeys(),
-				)
+			placeholderType, err := parsePlaceholderKey(keyStr)
+			if err != nil {
+				return nil, err
 			}
 
 			// Convert string to RuneSet (simple conversion)
 			config.Placeholders[placeholderType] = RuneSet(stringChars)
 		}
-	} else {
-		// Use defaults if no placeholders specified
+	} else if config.Placeholders == nil && tomlConfig.Phonetic.Syllables == nil {
+		// Use defaults if neither placeholders, syllables nor a preset are specified
 		config.Placeholders = DefaultPlaceholders
 	}
-	return config, preflight, nil
+
+	if tomlConfig.Phonetic.Syllables != nil {
+		config.Syllables = make(SyllableMap)
+
+		for keyStr, syllables := range tomlConfig.Phonetic.Syllables {
+			placeholderType, err := parsePlaceholderKey(keyStr)
+			if err != nil {
+				return nil, err
+			}
+
+			// Syllables replace a preset's characters for the same placeholder
+			if tomlConfig.Phonetic.Placeholders == nil {
+				delete(config.Placeholders, placeholderType)
+			}
+			config.Syllables[placeholderType] = syllables
+		}
+	}
+	return config, nil
 }
 
 // ValidatePhonidRC validates a PhonidConfig loaded from RC file with base encoding.
@@ -166,6 +341,31 @@ func ValidatePhonidRC(config *PhonidConfig) error {
 	return config.Validate()
 }
 
+// parsePlaceholderKey validates a TOML placeholder key and converts it to a PlaceholderType.
+func parsePlaceholderKey(keyStr string) (PlaceholderType, error) {
+	// Validate placeholder key - convert to runes first for proper UTF-8 handling
+	keyRunes := []rune(keyStr)
+	if len(keyRunes) != 1 {
+		return 0, fmt.Errorf(
+			"placeholder key '%s' must be single character",
+			keyStr,
+		)
+	}
+
+	placeholderType := PlaceholderType(keyRunes[0])
+
+	// Validate placeholder type is allowed
+	if _, isAllowed := AllowedPlaceholders[placeholderType]; !isAllowed {
+		return 0, fmt.Errorf(
+			"placeholder '%c' is not allowed. Valid placeholders: %v",
+			placeholderType,
+			getValidPlaceholderKeys(),
+		)
+	}
+
+	return placeholderType, nil
+}
+
 // getValidPlaceholderKeys returns a slice of valid placeholder characters for error messages.
 func getValidPlaceholderKeys() []string {
 	keys := make([]string, 0, len(AllowedPlaceholders))
@@ -197,20 +397,27 @@ func validateConfigPath(path string) error {
 		return errors.New("invalid path: directory traversal not allowed")
 	}
 
-	// Validate filename pattern: .phonidrc[.toml] or .*.phonidrc[.toml]
+	// Validate filename pattern: .phonidrc[.toml|.yaml|.json] or .*.phonidrc[.toml|.yaml|.json]
 	base := filepath.Base(cleaned)
 	if !IsValidPhonidRCFilename(base) {
-		return fmt.Errorf("invalid filename: must be '.phonidrc[.toml]' or '.<prefix>.phonidrc[.toml]', got '%s'", base)
+		return fmt.Errorf("invalid filename: must be '.phonidrc[.toml|.yaml|.json]' or "+
+			"'.<prefix>.phonidrc[.toml|.yaml|.json]', got '%s'", base)
 	}
 
 	return nil
 }
 
 // IsValidPhonidRCFilename checks if filename matches .phonidrc or .<prefix>.phonidrc pattern,
-// optionally with a .toml extension.
+// optionally with a .toml, .yaml or .json extension.
 func IsValidPhonidRCFilename(filename string) bool {
 	// Exact match: .phonidrc
-	filenameStripped, _ := strings.CutSuffix(filename, RcFileOptSuffix)
+	filenameStripped := filename
+	for _, suffix := range rcFileSuffixes[1:] {
+		if stripped, ok := strings.CutSuffix(filename, suffix); ok {
+			filenameStripped = stripped
+			break
+		}
+	}
 	if filenameStripped == RcFileName {
 		return true
 	}
@@ -269,15 +269,32 @@ func TestIsValidPhonidRCFilename(t *testing.T) {
 			want:     false,
 		},
 		{
-			name:     "wrong extension .yaml",
-			filename: ".phonidrc.yaml",
+			name:     "wrong extension .yml",
+			filename: ".phonidrc.yml",
 			want:     false,
 		},
 		{
 			name:     "prefixed with wrong extension",
-			filename: ".dev.phonidrc.json",
+			filename: ".dev.phonidrc.ini",
 			want:     false,
 		},
+		{
+			name:     "stacked extensions",
+			filename: ".phonidrc.json.toml",
+			want:     false,
+		},
+
+		// Valid: YAML and JSON
+		{
+			name:     "yaml extension",
+			filename: ".phonidrc.yaml",
+			want:     true,
+		},
+		{
+			name:     "prefixed with json extension",
+			filename: ".dev.phonidrc.json",
+			want:     true,
+		},
 
 		// Invalid: various malformed patterns
 		{
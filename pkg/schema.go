@@ -0,0 +1,318 @@
+package phonid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// JSONSchemaDialect is the JSON Schema version NewJSONSchema targets.
+	JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// DefaultSchemaFormat is the format name used when none is given.
+	DefaultSchemaFormat = "phonid"
+
+	// maxSchemaPatternBytes bounds the regex; phonotactic rules on many
+	// consecutive junctions can make the exact expression grow exponentially.
+	maxSchemaPatternBytes = 1 << 16
+
+	// minRangeRunes is the shortest run of consecutive runes written as a range.
+	minRangeRunes = 3
+
+	// regexMeta lists the characters escaped outside and inside character classes.
+	regexMeta      = `\^$.*+?()[]{}|`
+	regexClassMeta = `\]-^[`
+)
+
+var errSchemaTooComplex = errors.New("phonotactic rules make the exact pattern too large for a regular expression")
+
+type (
+	// JSONSchema is a JSON Schema string definition accepting exactly the words
+	// of a configuration. Pattern is an ECMA-262 regular expression that is also
+	// valid Go (RE2) syntax.
+	JSONSchema struct {
+		Schema      string   `json:"$schema,omitempty"`
+		Title       string   `json:"title,omitempty"`
+		Description string   `json:"description,omitempty"`
+		Type        string   `json:"type"`
+		Format      string   `json:"format"`
+		Pattern     string   `json:"pattern"`
+		MinLength   int      `json:"minLength"`
+		MaxLength   int      `json:"maxLength"`
+		Examples    []string `json:"examples,omitempty"`
+	}
+
+	// openAPIComponents is the part of an OpenAPI 3.1 document that registers
+	// reusable schemas.
+	openAPIComponents struct {
+		Components struct {
+			Schemas map[string]*JSONSchema `json:"schemas"`
+		} `json:"components"`
+	}
+
+	// symbolGroup is a set of symbols at one position that allow the same
+	// symbols at the next position, so the rest of the word is the same for all.
+	symbolGroup struct {
+		members []int
+		next    []int
+	}
+
+	// patternRegex builds the regular expression of one pattern.
+	patternRegex struct {
+		encoder *PatternEncoder
+		budget  int
+	}
+)
+
+// NewJSONSchema returns the JSON Schema string definition for config.
+// The pattern alternates one anchored expression per pattern, shortest first,
+// built from the symbols of each position. Phonotactic rules are honored, so
+// the pattern accepts exactly the words Decode accepts. An empty format
+// selects DefaultSchemaFormat.
+func NewJSONSchema(config *PhonidConfig, format string) (*JSONSchema, error) {
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		format = DefaultSchemaFormat
+	}
+
+	patterns := slices.Clone(encoder.patternEncoders)
+	slices.SortStableFunc(patterns, func(a, b *PatternEncoder) int {
+		if a.minRunes != b.minRunes {
+			return a.minRunes - b.minRunes
+		}
+		return a.maxRunes - b.maxRunes
+	})
+
+	schema := &JSONSchema{
+		Schema:    JSONSchemaDialect,
+		Title:     format,
+		Type:      "string",
+		Format:    format,
+		MinLength: patterns[0].minRunes,
+	}
+
+	names := make([]string, 0, len(patterns))
+	alternatives := make([]string, 0, len(patterns))
+	budget := maxSchemaPatternBytes
+	for _, pattern := range patterns {
+		builder := &patternRegex{encoder: pattern, budget: budget}
+		regex, err := builder.build()
+		if err != nil {
+			return nil, fmt.Errorf("pattern '%s': %w", pattern.pattern, err)
+		}
+		budget -= len(regex)
+
+		// A word from the middle of the range shows more variety than 0
+		example, err := pattern.Encode(pattern.totalCombinations / 2)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, pattern.pattern)
+		alternatives = append(alternatives, regex)
+		schema.Examples = append(schema.Examples, example)
+		schema.MaxLength = max(schema.MaxLength, pattern.maxRunes)
+	}
+
+	schema.Pattern = "^(?:" + strings.Join(alternatives, "|") + ")$"
+	schema.Description = fmt.Sprintf("Phonetic ID matching one of the patterns %s", strings.Join(names, ", "))
+	return schema, nil
+}
+
+// MarshalOpenAPI returns an OpenAPI 3.1 document fragment registering the
+// schema and its format under components/schemas/name, ready to be
+// referenced with $ref from API specifications.
+func (s *JSONSchema) MarshalOpenAPI(name string) ([]byte, error) {
+	if name == "" {
+		name = s.Format
+	}
+
+	// OpenAPI 3.1 schemas inherit the dialect from the document
+	component := *s
+	component.Schema = ""
+
+	var document openAPIComponents
+	document.Components.Schemas = map[string]*JSONSchema{name: &component}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// build returns the regex for all words of the pattern.
+//
+// Without phonotactic rules this is one symbol set per position. With rules,
+// the symbols at a position are grouped by which symbols they allow next, and
+// each group continues with its own alternative. Positions reached with a
+// single set of allowed symbols cut the word into independent segments, which
+// keeps the expression small when rules affect only some junctions.
+func (p *patternRegex) build() (string, error) {
+	positions := len(p.encoder.positions)
+
+	// reachable[i] holds the distinct sets of symbols allowed at position i
+	reachable := make([][][]int, positions+1)
+	reachable[0] = [][]int{p.live(0, nil)}
+	for i := range positions {
+		for _, set := range reachable[i] {
+			for _, group := range p.groups(i, set) {
+				if !slices.ContainsFunc(reachable[i+1], func(s []int) bool { return slices.Equal(s, group.next) }) {
+					reachable[i+1] = append(reachable[i+1], group.next)
+				}
+			}
+		}
+	}
+
+	var regex strings.Builder
+	start := 0
+	for end := 1; end <= positions; end++ {
+		if len(reachable[end]) > 1 {
+			continue
+		}
+		segment, err := p.segment(start, reachable[start][0], end)
+		if err != nil {
+			return "", err
+		}
+		regex.WriteString(segment)
+		start = end
+	}
+	return regex.String(), nil
+}
+
+// segment returns the regex for positions i to end-1, starting with set.
+func (p *patternRegex) segment(i int, set []int, end int) (string, error) {
+	groups := p.groups(i, set)
+	alternatives := make([]string, 0, len(groups))
+	for _, group := range groups {
+		alternative := p.symbols(i, group.members)
+		p.budget -= len(alternative)
+		if p.budget < 0 {
+			return "", errSchemaTooComplex
+		}
+
+		if i+1 < end {
+			rest, err := p.segment(i+1, group.next, end)
+			if err != nil {
+				return "", err
+			}
+			alternative += rest
+		}
+		alternatives = append(alternatives, alternative)
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	slices.Sort(alternatives)
+	return "(?:" + strings.Join(alternatives, "|") + ")", nil
+}
+
+// groups partitions set by the symbols each member allows at position i+1.
+func (p *patternRegex) groups(i int, set []int) []symbolGroup {
+	var groups []symbolGroup
+	for _, symbol := range set {
+		next := p.next(i, symbol)
+		index := slices.IndexFunc(groups, func(g symbolGroup) bool { return slices.Equal(g.next, next) })
+		if index < 0 {
+			groups = append(groups, symbolGroup{next: next})
+			index = len(groups) - 1
+		}
+		groups[index].members = append(groups[index].members, symbol)
+	}
+	return groups
+}
+
+// next returns the symbols allowed at position i+1 after symbol at position i.
+// Past the last position it returns nil.
+func (p *patternRegex) next(i, symbol int) []int {
+	if i+1 == len(p.encoder.positions) {
+		return nil
+	}
+	return p.live(i+1, func(candidate int) bool {
+		return p.encoder.transitions == nil || p.encoder.transitions[i+1][symbol][candidate]
+	})
+}
+
+// live returns the symbols at position i that accept and can complete a word.
+// A nil accept admits every symbol.
+func (p *patternRegex) live(i int, accept func(int) bool) []int {
+	var symbols []int
+	for symbol := range p.encoder.positions[i].base {
+		if accept != nil && !accept(symbol) {
+			continue
+		}
+		if p.encoder.suffixCounts != nil && p.encoder.suffixCounts[i][symbol] == 0 {
+			continue
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// symbols returns the regex matching any of the given symbols at position i:
+// a character class for single runes, an alternation for syllables.
+func (p *patternRegex) symbols(i int, members []int) string {
+	symbols := make([]string, 0, len(members))
+	singleRunes := true
+	for _, member := range members {
+		symbol := p.encoder.positions[i].symbols[member]
+		symbols = append(symbols, symbol)
+		singleRunes = singleRunes && utf8.RuneCountInString(symbol) == 1
+	}
+	slices.Sort(symbols)
+
+	switch {
+	case len(symbols) == 1:
+		return escapeRegex(symbols[0], regexMeta)
+	case singleRunes:
+		return runeClass(symbols)
+	}
+
+	for j, symbol := range symbols {
+		symbols[j] = escapeRegex(symbol, regexMeta)
+	}
+	return "(?:" + strings.Join(symbols, "|") + ")"
+}
+
+// runeClass returns a character class for sorted single-rune symbols,
+// collapsing runs of consecutive runes into ranges.
+func runeClass(symbols []string) string {
+	runes := make([]rune, len(symbols))
+	for j, symbol := range symbols {
+		runes[j], _ = utf8.DecodeRuneInString(symbol)
+	}
+
+	var class strings.Builder
+	class.WriteByte('[')
+	for j := 0; j < len(runes); {
+		k := j
+		for k+1 < len(runes) && runes[k+1] == runes[k]+1 {
+			k++
+		}
+		if k-j+1 >= minRangeRunes {
+			class.WriteString(escapeRegex(string(runes[j]), regexClassMeta) + "-" +
+				escapeRegex(string(runes[k]), regexClassMeta))
+			j = k + 1
+			continue
+		}
+		class.WriteString(escapeRegex(string(runes[j]), regexClassMeta))
+		j++
+	}
+	class.WriteByte(']')
+	return class.String()
+}
+
+// escapeRegex backslash-escapes the characters of s that appear in meta.
+func escapeRegex(s, meta string) string {
+	var escaped strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(meta, r) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,156 @@
+package phonid_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+// assertSchemaExact checks that the schema pattern accepts exactly the words
+// Decode accepts, among all sequences of up to maxSymbols symbols.
+func assertSchemaExact(t *testing.T, config *PhonidConfig, symbols []string, maxSymbols int) {
+	t.Helper()
+	schema, err := NewJSONSchema(config, "")
+	if err != nil {
+		t.Fatalf("NewJSONSchema() error = %v", err)
+	}
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := regexp.MustCompile(schema.Pattern)
+
+	accepted := 0
+	words := []string{""}
+	for range maxSymbols {
+		var longer []string
+		for _, word := range words {
+			for _, symbol := range symbols {
+				longer = append(longer, word+symbol)
+			}
+		}
+		words = longer
+
+		for _, word := range words {
+			_, err := encoder.Decode(word)
+			if matched := pattern.MatchString(word); matched != (err == nil) {
+				t.Fatalf("pattern %s matches %q = %v, but Decode error = %v", schema.Pattern, word, matched, err)
+			}
+			if err == nil {
+				accepted++
+			}
+		}
+	}
+	if accepted == 0 {
+		t.Fatal("no word was accepted; the test alphabet does not cover the config")
+	}
+}
+
+func TestNewJSONSchema(t *testing.T) {
+	schema, err := NewJSONSchema(&PhonidConfig{
+		Patterns: []string{"CVCVC", "CVC"},
+		Placeholders: PlaceholderMap{
+			Consonant: RuneSet("bdkst"),
+			Vowel:     RuneSet("aeiou"),
+		},
+	}, "order-id")
+	if err != nil {
+		t.Fatalf("NewJSONSchema() error = %v", err)
+	}
+
+	want := "^(?:[bdkst][aeiou][bdkst]|[bdkst][aeiou][bdkst][aeiou][bdkst])$"
+	if schema.Pattern != want {
+		t.Errorf("Pattern = %s, want %s", schema.Pattern, want)
+	}
+	if schema.Type != "string" || schema.Format != "order-id" || schema.Schema != JSONSchemaDialect {
+		t.Errorf("schema = %+v", schema)
+	}
+	if schema.MinLength != 3 || schema.MaxLength != 5 {
+		t.Errorf("length = %d-%d, want 3-5", schema.MinLength, schema.MaxLength)
+	}
+
+	pattern := regexp.MustCompile(schema.Pattern)
+	if len(schema.Examples) != 2 {
+		t.Fatalf("Examples = %v, want one per pattern", schema.Examples)
+	}
+	for _, example := range schema.Examples {
+		if !pattern.MatchString(example) {
+			t.Errorf("example %q does not match the pattern", example)
+		}
+	}
+}
+
+func TestNewJSONSchema_RangesAndEscaping(t *testing.T) {
+	schema, err := NewJSONSchema(&ProQuintConfig, "")
+	if err != nil {
+		t.Fatalf("NewJSONSchema() error = %v", err)
+	}
+
+	word := "[bdf-hj-npr-tvz][aiou][bdf-hj-npr-tvz][aiou][bdf-hj-npr-tvz]"
+	if want := "^(?:" + word + "-" + word + ")$"; schema.Pattern != want {
+		t.Errorf("Pattern = %s, want %s", schema.Pattern, want)
+	}
+	if schema.Format != DefaultSchemaFormat {
+		t.Errorf("Format = %q, want %q", schema.Format, DefaultSchemaFormat)
+	}
+	if !regexp.MustCompile(schema.Pattern).MatchString("lusab-babad") {
+		t.Errorf("pattern %s rejects a proquint", schema.Pattern)
+	}
+}
+
+func TestNewJSONSchema_Exact(t *testing.T) {
+	t.Run("phonotactics", func(t *testing.T) {
+		symbols := []string{"a", "e", "i", "o", "u", "b", "k", "p", "s", "t", "x", "z"}
+		assertSchemaExact(t, phonotacticConfig(), symbols, 5)
+	})
+
+	t.Run("syllables", func(t *testing.T) {
+		symbols := []string{"a", "e", "i", "o", "sh", "th", "ng", "k", "m", "s", "h"}
+		assertSchemaExact(t, syllableConfig(), symbols, 5)
+	})
+
+	t.Run("permutation", func(t *testing.T) {
+		config := phonotacticConfig()
+		config.Permutation = &PermutationConfig{Seed: 7}
+		symbols := []string{"a", "e", "i", "o", "u", "b", "k", "p", "s", "t", "x", "z"}
+		assertSchemaExact(t, config, symbols, 5)
+	})
+}
+
+func TestNewJSONSchema_InvalidConfig(t *testing.T) {
+	if _, err := NewJSONSchema(&PhonidConfig{Patterns: []string{"CVCV"}}, ""); err == nil {
+		t.Error("NewJSONSchema() expected error for an invalid config")
+	}
+}
+
+func TestJSONSchema_MarshalOpenAPI(t *testing.T) {
+	schema, err := NewJSONSchema(&ProQuintConfig, "proquint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := schema.MarshalOpenAPI("ProquintID")
+	if err != nil {
+		t.Fatalf("MarshalOpenAPI() error = %v", err)
+	}
+
+	var document struct {
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("invalid document %s: %v", data, err)
+	}
+	component, ok := document.Components.Schemas["ProquintID"]
+	if !ok {
+		t.Fatalf("components.schemas lacks ProquintID:\n%s", data)
+	}
+	if component["format"] != "proquint" || component["pattern"] != schema.Pattern {
+		t.Errorf("component = %v", component)
+	}
+	if _, ok := component["$schema"]; ok {
+		t.Error("component repeats $schema; OpenAPI 3.1 documents declare the dialect")
+	}
+}
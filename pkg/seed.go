@@ -0,0 +1,187 @@
+package phonid
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SeedKDFSalt is the fixed PBKDF2 salt for passphrase seeds. Changing it
+	// changes every derived seed, so it is versioned.
+	SeedKDFSalt = "phonid/seed/v1"
+	// SeedKDFIterations is the PBKDF2-HMAC-SHA256 iteration count for passphrase seeds.
+	SeedKDFIterations = 100_000
+
+	seedEnvPrefix = "env:"
+	seedHexPrefix = "0x"
+	hexDigits     = "0123456789abcdef"
+	seedBytes     = 8
+)
+
+var (
+	errLiteralSeed = errors.New(`strict_seeds forbids literal seeds; use { env = "NAME" }, { file = "PATH" } or "env:NAME"`)
+	errMissingSeed = errors.New(`strict_seeds requires a seed reference such as { env = "NAME" }`)
+)
+
+// ParseSeed converts a seed string to the full uint64 seed width:
+//
+//   - "env:NAME" reads the environment variable NAME and parses its value
+//     as a hex or passphrase seed
+//   - "0x" followed by 1-16 hex digits is used verbatim; more digits are an error
+//   - anything else, including "0x" followed by other characters, is a
+//     passphrase, derived with DeriveSeed
+//
+// The same string always yields the same seed, so seeds can be kept out of the
+// repository and still reproduce published IDs.
+func ParseSeed(value string) (uint64, error) {
+	if name, ok := strings.CutPrefix(value, seedEnvPrefix); ok {
+		if name == "" {
+			return 0, errors.New("seed env reference needs a variable name")
+		}
+		resolved, ok := os.LookupEnv(name)
+		if !ok || resolved == "" {
+			return 0, fmt.Errorf("seed environment variable %s is not set", name)
+		}
+		if strings.HasPrefix(resolved, seedEnvPrefix) {
+			return 0, fmt.Errorf("seed environment variable %s must not reference another variable", name)
+		}
+		return ParseSeed(resolved)
+	}
+
+	digits, ok := strings.CutPrefix(strings.ToLower(value), seedHexPrefix)
+	if ok && strings.Trim(digits, hexDigits) == "" {
+		if digits == "" || len(digits) > 2*seedBytes {
+			return 0, fmt.Errorf("hex seed must have 1 to %d digits, got %d", 2*seedBytes, len(digits))
+		}
+		return strconv.ParseUint(digits, 16, 64)
+	}
+
+	if value == "" {
+		return 0, errors.New("seed passphrase must not be empty")
+	}
+	return DeriveSeed(value), nil
+}
+
+// DeriveSeed derives a seed from a passphrase: the first 8 bytes, big-endian,
+// of PBKDF2-HMAC-SHA256(passphrase, SeedKDFSalt, SeedKDFIterations).
+func DeriveSeed(passphrase string) uint64 {
+	// PBKDF2 only fails for invalid key lengths, which seedBytes is not
+	key, _ := pbkdf2.Key(sha256.New, passphrase, []byte(SeedKDFSalt), SeedKDFIterations, seedBytes)
+	return binary.BigEndian.Uint64(key)
+}
+
+// resolveSeed converts a decoded rc seed value to a seed. Values are integers,
+// strings accepted by ParseSeed, or a reference table { env = "NAME" } or
+// { file = "PATH" }, where a relative PATH resolves against dir. In strict
+// mode only references are accepted, and a missing seed is an error instead
+// of the public seed 0, so an rc file can be committed while the secret is
+// injected at deploy time.
+func resolveSeed(value any, strict bool, dir string) (uint64, error) {
+	if strict {
+		if err := checkStrictSeed(value); err != nil {
+			return 0, err
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case map[string]any:
+		return resolveSeedReference(v, dir)
+	case string:
+		return ParseSeed(v)
+	case int64, int, uint64, json.Number:
+		return integerSeed(v)
+	default:
+		return 0, fmt.Errorf("seed must be an integer, a string or an env/file reference, got %T", value)
+	}
+}
+
+// checkStrictSeed rejects missing and literal seeds without resolving
+// references.
+func checkStrictSeed(value any) error {
+	switch v := value.(type) {
+	case nil:
+		return errMissingSeed
+	case string:
+		if !strings.HasPrefix(v, seedEnvPrefix) {
+			return errLiteralSeed
+		}
+	case int64, int, uint64, json.Number:
+		return errLiteralSeed
+	}
+	return nil
+}
+
+// integerSeed converts an integer decoded by TOML (int64), YAML (int, uint64)
+// or JSON (json.Number) to a seed.
+func integerSeed(value any) (uint64, error) {
+	switch v := value.(type) {
+	case uint64:
+		return v, nil
+	case int:
+		return integerSeed(int64(v))
+	case json.Number:
+		if seed, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return seed, nil
+		}
+		signed, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("seed must be an integer, got %s", v)
+		}
+		return integerSeed(signed)
+	}
+
+	signed, _ := value.(int64)
+	if signed < 0 {
+		return 0, fmt.Errorf("value must be non-negative, got %d", signed)
+	}
+	return uint64(signed), nil
+}
+
+// resolveSeedReference reads a seed from an environment variable or a file.
+// Relative file paths resolve against dir, the directory of the rc file.
+// The referenced value is parsed like a seed string (hex or passphrase).
+func resolveSeedReference(reference map[string]any, dir string) (uint64, error) {
+	if len(reference) != 1 {
+		return 0, errors.New(`seed reference must have exactly one of "env" or "file"`)
+	}
+
+	kind := slices.Collect(maps.Keys(reference))[0]
+	name, ok := reference[kind].(string)
+	if !ok || name == "" {
+		return 0, fmt.Errorf("seed %s reference must be a non-empty string", kind)
+	}
+
+	switch kind {
+	case "env":
+		return ParseSeed(seedEnvPrefix + name)
+	case "file":
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		// #nosec G304 -- the rc file author chooses the secret location
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read seed file: %w", err)
+		}
+		// Secret files usually end with a newline
+		content := strings.TrimRight(string(data), "\r\n")
+		if strings.HasPrefix(content, seedEnvPrefix) {
+			return 0, fmt.Errorf("seed file %s must not reference an environment variable", name)
+		}
+		return ParseSeed(content)
+	default:
+		return 0, fmt.Errorf(`unknown seed reference %q (allowed: "env", "file")`, kind)
+	}
+}
@@ -0,0 +1,280 @@
+package phonid_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestParseSeed(t *testing.T) {
+	t.Setenv("PHONID_TEST_SEED", "0xdeadbeef")
+	t.Setenv("PHONID_TEST_LOOP", "env:PHONID_TEST_SEED")
+
+	tests := []struct {
+		value   string
+		want    uint64
+		wantErr bool
+	}{
+		{"0xffffffffffffffff", 0xFFFFFFFFFFFFFFFF, false},
+		{"0X2A", 42, false},
+		// PBKDF2-HMAC-SHA256, salt "phonid/seed/v1", 100000 iterations, first 8 bytes
+		{"correct horse battery staple", 4289187114843343036, false},
+		{"env:PHONID_TEST_SEED", 0xdeadbeef, false},
+		{"env:PHONID_TEST_UNSET", 0, true},
+		{"env:PHONID_TEST_LOOP", 0, true},
+		{"env:", 0, true},
+		{"0x", 0, true},
+		{"0x1ffffffffffffffff", 0, true},
+		// Not hex, so derived as passphrases
+		{"0xnothex", 16243172137784733745, false},
+		{"0xford rules", 13900934623906185225, false},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSeed(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSeed(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSeed(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseConfigRCSeed(t *testing.T) {
+	t.Setenv("PHONID_TEST_SEED", "0xffffffffffffffff")
+
+	content := `
+[shuffle]
+rounds = 4
+seed = "env:PHONID_TEST_SEED"
+
+[phonetic]
+preset = "proquint"
+
+[phonetic.permutation]
+seed = "0x3039"
+
+[[preflight]]
+input = 1337
+output = "vovov-vuniz"
+`
+	config, preflight, err := ParseConfigRC(content)
+	if err != nil {
+		t.Fatalf("ParseConfigRC() error = %v", err)
+	}
+	if config.Shuffle.Seed != 0xFFFFFFFFFFFFFFFF || config.Shuffle.Rounds != 4 {
+		t.Errorf("Shuffle = %+v, want seed 0xffffffffffffffff and 4 rounds", config.Shuffle)
+	}
+	if config.Phonetic.Permutation.Seed != 12345 {
+		t.Errorf("Permutation.Seed = %d, want 12345", config.Phonetic.Permutation.Seed)
+	}
+
+	encoder, err := NewPhoneticEncoder(config.Phonetic)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+	if err := encoder.ValidatePreflight(preflight); err != nil {
+		t.Errorf("ValidatePreflight() error = %v", err)
+	}
+}
+
+func TestParseConfigRCInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		shuffle string
+	}{
+		{"negative seed", "seed = -1"},
+		{"unset env", `seed = "env:PHONID_TEST_UNSET"`},
+		{"float seed", "seed = 1.5"},
+		{"mismatched bit width", "bit_width = 7"},
+		{"negative bit width", "bit_width = -1"},
+		{"too many rounds", "rounds = 99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "[shuffle]\n" + tt.shuffle + `
+
+[phonetic]
+preset = "proquint"
+
+[[preflight]]
+input = 0
+output = "babab"
+`
+			if _, _, err := ParseConfigRC(content); err == nil {
+				t.Error("ParseConfigRC() should fail")
+			}
+		})
+	}
+}
+
+func TestParsePhonidRCSeedReferences(t *testing.T) {
+	t.Setenv("PHONID_TEST_SEED", "0x3039")
+
+	secret := filepath.Join(t.TempDir(), "phonid")
+	if err := os.WriteFile(secret, []byte("0x3039\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	for _, reference := range []string{
+		`{ env = "PHONID_TEST_SEED" }`,
+		`{ file = "` + filepath.ToSlash(secret) + `" }`,
+		`"env:PHONID_TEST_SEED"`,
+	} {
+		content := `
+strict_seeds = true
+
+[phonetic]
+preset = "proquint"
+
+[phonetic.permutation]
+seed = ` + reference + `
+
+[[preflight]]
+input = 1337
+output = "vovov-vuniz"
+`
+		config, preflight, err := ParsePhonidRC(content)
+		if err != nil {
+			t.Fatalf("ParsePhonidRC(%s) error = %v", reference, err)
+		}
+
+		encoder, err := NewPhoneticEncoder(config)
+		if err != nil {
+			t.Fatalf("NewPhoneticEncoder() error = %v", err)
+		}
+		if err := encoder.ValidatePreflight(preflight); err != nil {
+			t.Errorf("seed %s: ValidatePreflight() error = %v", reference, err)
+		}
+	}
+}
+
+func TestParseConfigRCSeedReferencesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"strict literal integer", "strict_seeds = true\n[shuffle]\nseed = 12345"},
+		{"strict literal hex", "strict_seeds = true\n[shuffle]\nseed = \"0x3039\""},
+		{"strict literal passphrase", "strict_seeds = true\n[shuffle]\nseed = \"hunter2\""},
+		{"strict missing seed", "strict_seeds = true\n[shuffle]\nrounds = 4"},
+		{"strict missing permutation seed", "strict_seeds = true\n[phonetic.permutation]\nper_position = true"},
+		{"unset env", "[shuffle]\nseed = { env = \"PHONID_TEST_UNSET\" }"},
+		{"missing file", "[shuffle]\nseed = { file = \"/nonexistent/phonid\" }"},
+		{"unknown reference", "[shuffle]\nseed = { vault = \"phonid\" }"},
+		{"two references", "[shuffle]\nseed = { env = \"A\", file = \"B\" }"},
+		{"empty reference", "[shuffle]\nseed = { env = \"\" }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.content + `
+
+[phonetic]
+preset = "proquint"
+
+[[preflight]]
+input = 0
+output = "babab"
+`
+			if _, _, err := ParseConfigRC(content); err == nil {
+				t.Error("ParseConfigRC() should fail")
+			}
+		})
+	}
+}
+
+func TestParsePhonidRCStrictShuffleSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		shuffle string
+		wantErr bool
+	}{
+		{"literal seed", "seed = 42", true},
+		{"missing seed", "rounds = 4", true},
+		{"unresolved reference", `seed = { env = "PHONID_TEST_UNSET" }`, false},
+		{"no shuffling", "rounds = 0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "strict_seeds = true\n\n[shuffle]\n" + tt.shuffle + `
+
+[phonetic]
+preset = "proquint"
+
+[[preflight]]
+input = 0
+output = "babab-babab"
+`
+			_, _, err := ParsePhonidRC(content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePhonidRC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseConfigRCStrictSeedsWithoutShuffle(t *testing.T) {
+	config, _, err := ParseConfigRC(`
+strict_seeds = true
+
+[phonetic]
+preset = "proquint"
+
+[[preflight]]
+input = 0
+output = "babab-babab"
+`)
+	if err != nil {
+		t.Fatalf("ParseConfigRC() error = %v", err)
+	}
+	if config.Shuffle.Rounds != 0 || config.Shuffle.Seed != 0 {
+		t.Errorf("Shuffle = %+v, want no shuffling", config.Shuffle)
+	}
+}
+
+func TestLoadConfigRCSeedFileRelativeToRCFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "secrets"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secrets", "phonid"), []byte("0x3039\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rc := filepath.Join(dir, RcFileName)
+	content := `
+strict_seeds = true
+
+[phonetic]
+preset = "proquint"
+
+[shuffle]
+rounds = 4
+seed = { file = "secrets/phonid" }
+
+[[preflight]]
+input = 0
+output = "babab-babab"
+`
+	if err := os.WriteFile(rc, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The working directory must not matter
+	t.Chdir(t.TempDir())
+	config, _, err := LoadConfigRC(rc)
+	if err != nil {
+		t.Fatalf("LoadConfigRC() error = %v", err)
+	}
+	if config.Shuffle.Seed != 0x3039 {
+		t.Errorf("Seed = %d, want %d", config.Shuffle.Seed, 0x3039)
+	}
+}
@@ -1,46 +1,121 @@
 package phonid
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash/fnv"
 )
 
 const (
+	// MinBitWidth is the minimum supported bit width.
+	MinBitWidth = 4
 	// MaxBitWidth is the maximum supported bit width (uint64 size).
 	MaxBitWidth = 64
 	// MinRounds is the minimum number of rounds to shuffle.
 	MinRounds = 0
 	// MaxRounds is the maximum number of rounds to shuffle.
 	MaxRounds = 12
+	// MinKeyedRounds is the minimum number of rounds for keyed round functions.
+	// Four rounds of a pseudo-random function yield a strong pseudo-random
+	// permutation (Luby-Rackoff); fewer would let the key leak structure.
+	MinKeyedRounds = 4
+	// MinKeyBytes is the minimum secret key size for keyed round functions (128 bits).
+	MinKeyBytes = 16
+
+	// RoundFunctionFNV derives round outputs from the seed with FNV-1a.
+	// Fast and stable, but not a secret: anyone seeing a few ID pairs can recover the order.
+	RoundFunctionFNV RoundFunction = "fnv"
+	// RoundFunctionHMACSHA256 uses HMAC-SHA256 keyed with a secret as round function.
+	RoundFunctionHMACSHA256 RoundFunction = "hmac-sha256"
 )
 
 type (
+	// RoundFunction selects the pseudo-random function used in each Feistel round.
+	RoundFunction string
+
 	// ShuffleConfig holds Feistel shuffler configuration.
 	ShuffleConfig struct {
-		BitWidth int    `default:"0"` // 0 means auto-detect from phonetic patterns
-		Rounds   int    `default:"0"`
-		Seed     uint64 `default:"0"`
+		BitWidth      int           `default:"0"` // 0 means auto-detect from phonetic patterns
+		Rounds        int           `default:"0"`
+		Seed          uint64        `default:"0"`
+		RoundFunction RoundFunction // Empty selects RoundFunctionFNV
+		Key           []byte        // Secret for keyed round functions, at least MinKeyBytes long
+		// Encoding version of the Feistel network; 0 selects DefaultEncodingVersion.
+		// Config sets it from Config.EncodingVersion.
+		EncodingVersion int
 	}
 
 	// FeistelShuffler provides bijective integer shuffling using Feistel networks
 	// Supports configurable number space size and uses standard Go libraries.
 	FeistelShuffler struct {
+		options   ShuffleConfig
 		rounds    int      // Number of Feistel rounds (3-6 recommended)
 		bitWidth  int      // Total bit width of the number space
 		halfBits  int      // Bits per half (left/right)
-		mask      uint64   // Mask for half-width values
 		roundKeys []uint64 // Round keys derived from seed
+		secret    []byte   // HMAC key; nil selects the FNV round function
+		evenSplit bool     // Encoding version 1: both halves use halfBits, even for odd widths
 	}
 )
 
-// Validate checks if the shuffle config is valid.
+// Validate checks if the shuffle config is valid. It is the single source of
+// truth for shuffler limits: every constructor runs it.
 func (sc *ShuffleConfig) Validate() error {
-	if sc.BitWidth < 4 || sc.BitWidth > 64 {
-		return fmt.Errorf("bit_width must be between 4 and 64, got %d", sc.BitWidth)
+	if sc.BitWidth < MinBitWidth || sc.BitWidth > MaxBitWidth {
+		return fmt.Errorf("bit_width must be between %d and %d, got %d", MinBitWidth, MaxBitWidth, sc.BitWidth)
+	}
+	if err := validateRoundFunction(sc.RoundFunction, sc.Key); err != nil {
+		return err
+	}
+	if sc.EncodingVersion != 0 {
+		if err := validateEncodingVersion(sc.EncodingVersion); err != nil {
+			return err
+		}
+	}
+
+	minRounds, maxRounds := RoundLimits(sc.RoundFunction)
+	if sc.Rounds < minRounds || sc.Rounds > maxRounds {
+		return fmt.Errorf("rounds must be between %d and %d for %s, got %d",
+			minRounds, maxRounds, sc.roundFunction(), sc.Rounds)
+	}
+	return nil
+}
+
+// roundFunction returns the round function, resolving the empty default.
+func (sc *ShuffleConfig) roundFunction() RoundFunction {
+	if sc.RoundFunction == "" {
+		return RoundFunctionFNV
 	}
-	if sc.Rounds < MinRounds || sc.Rounds > MaxRounds {
-		return fmt.Errorf("rounds must be between %d and %d, got %d", MinRounds, MaxRounds, sc.Rounds)
+	return sc.RoundFunction
+}
+
+// RoundLimits returns the accepted range of Feistel rounds for a round function.
+// Unkeyed shuffling allows 0 rounds to preserve linear order; keyed shuffling
+// requires enough rounds for the secret to matter.
+func RoundLimits(roundFunction RoundFunction) (int, int) {
+	if roundFunction == RoundFunctionHMACSHA256 {
+		return MinKeyedRounds, MaxRounds
+	}
+	return MinRounds, MaxRounds
+}
+
+// validateRoundFunction checks that the key matches the selected round function.
+func validateRoundFunction(roundFunction RoundFunction, key []byte) error {
+	switch roundFunction {
+	case "", RoundFunctionFNV:
+		if len(key) > 0 {
+			return errors.New("key is only used by keyed round functions; set round_function to hmac-sha256")
+		}
+	case RoundFunctionHMACSHA256:
+		if len(key) < MinKeyBytes {
+			return fmt.Errorf("key must be at least %d bytes for %s, got %d", MinKeyBytes, roundFunction, len(key))
+		}
+	default:
+		return fmt.Errorf("unknown round function %q (allowed: %s, %s)",
+			roundFunction, RoundFunctionFNV, RoundFunctionHMACSHA256)
 	}
 	return nil
 }
@@ -50,35 +125,59 @@ func (sc *ShuffleConfig) Validate() error {
 // rounds: number of Feistel rounds (3-6 recommended. "0" will preserve linear order)
 // seed: seed value for generating round keys
 func NewFeistelShuffler(bitWidth, rounds int, seed uint64) (*FeistelShuffler, error) {
-	if bitWidth < 4 || bitWidth > 64 {
-		return nil, fmt.Errorf("bitWidth must be between 4 and 64, got %d", bitWidth)
+	return NewFeistelShufflerWithOptions(ShuffleConfig{
+		BitWidth: bitWidth,
+		Rounds:   rounds,
+		Seed:     seed,
+	})
+}
+
+// NewFeistelShufflerWithOptions creates a shuffler from a ShuffleConfig.
+// All other constructors delegate here, so a config that passes Validate
+// always constructs.
+func NewFeistelShufflerWithOptions(options ShuffleConfig) (*FeistelShuffler, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	options.RoundFunction = options.roundFunction()
+	if options.EncodingVersion == 0 {
+		options.EncodingVersion = DefaultEncodingVersion
 	}
-	if rounds < 0 || rounds > 10 {
-		return nil, fmt.Errorf("rounds must be between 0 and 10, got %d", rounds)
+	options.Key = append([]byte(nil), options.Key...)
+
+	halfBits := options.BitWidth >> 1 // Right shift by 1 == divide by 2
+	fs := &FeistelShuffler{
+		options:   options,
+		rounds:    options.Rounds,
+		bitWidth:  options.BitWidth,
+		halfBits:  halfBits,
+		roundKeys: make([]uint64, options.Rounds),
+		evenSplit: options.EncodingVersion < versionUnevenSplit,
 	}
 
-	halfBits := bitWidth >> 1 // Right shift by 1 == divide by 2
-	mask := (uint64(1) << halfBits) - 1
+	if options.RoundFunction == RoundFunctionHMACSHA256 {
+		// The secret carries the entropy; round keys only separate the rounds
+		for i := range fs.roundKeys {
+			// #nosec G115 -- i is bounded by validation (0-MaxRounds), no overflow possible
+			fs.roundKeys[i] = uint64(i)
+		}
+		fs.secret = options.Key
+		return fs, nil
+	}
 
 	// Generate round keys from seed using FNV hash
-	roundKeys := make([]uint64, rounds)
 	h := fnv.New64a()
-	for i := range rounds {
+	for i := range fs.roundKeys {
 		h.Reset()
-		_ = binary.Write(h, binary.LittleEndian, seed)
-		// #nosec G115 -- i is bounded by validation (0-10), no overflow possible
+		_ = binary.Write(h, binary.LittleEndian, options.Seed)
+		// #nosec G115 -- i is bounded by validation (0-MaxRounds), no overflow possible
 		roundIndex := uint64(i)
 		_ = binary.Write(h, binary.LittleEndian, roundIndex)
-		roundKeys[i] = h.Sum64()
+		fs.roundKeys[i] = h.Sum64()
 	}
 
-	return &FeistelShuffler{
-		rounds:    rounds,
-		bitWidth:  bitWidth,
-		halfBits:  halfBits,
-		mask:      mask,
-		roundKeys: roundKeys,
-	}, nil
+	return fs, nil
 }
 
 // Encode performs bijective shuffling of input value.
@@ -92,9 +191,10 @@ func (fs *FeistelShuffler) Encode(input uint64) (uint64, error) {
 		}
 	}
 
-	// Split input into left and right halves
-	left := input >> fs.halfBits
-	right := input & fs.mask
+	// Split input into left and right halves; the half sizes swap with every round
+	leftBits, rightBits := fs.halves()
+	left := input >> rightBits
+	right := input & halfMask(rightBits)
 
 	// Feistel rounds
 	for i := range fs.rounds {
@@ -104,11 +204,12 @@ func (fs *FeistelShuffler) Encode(input uint64) (uint64, error) {
 		// XOR with left half and swap
 		newRight := left ^ roundOutput
 		left = right
-		right = newRight & fs.mask // Ensure it stays within half-bit width
+		right = newRight & halfMask(leftBits) // Ensure it stays within half-bit width
+		leftBits, rightBits = rightBits, leftBits
 	}
 
 	// Combine halves back together
-	return (left << fs.halfBits) | right, nil
+	return (left << rightBits) | right, nil
 }
 
 // Decode performs bijective reverse shuffling (inverse of Encode).
@@ -122,9 +223,13 @@ func (fs *FeistelShuffler) Decode(encoded uint64) (uint64, error) {
 		}
 	}
 
-	// Split encoded value into left and right halves
-	left := encoded >> fs.halfBits
-	right := encoded & fs.mask
+	// Split encoded value into left and right halves, sized as Encode left them
+	leftBits, rightBits := fs.halves()
+	if fs.rounds%2 == 1 {
+		leftBits, rightBits = rightBits, leftBits
+	}
+	left := encoded >> rightBits
+	right := encoded & halfMask(rightBits)
 
 	// Reverse Feistel rounds (apply in reverse order)
 	for i := fs.rounds - 1; i >= 0; i-- {
@@ -134,11 +239,32 @@ func (fs *FeistelShuffler) Decode(encoded uint64) (uint64, error) {
 		// XOR with right half and swap
 		newLeft := right ^ roundOutput
 		right = left
-		left = newLeft & fs.mask // Ensure it stays within half-bit width
+		left = newLeft & halfMask(rightBits) // Ensure it stays within half-bit width
+		leftBits, rightBits = rightBits, leftBits
 	}
 
 	// Combine halves back together
-	return (left << fs.halfBits) | right, nil
+	return (left << rightBits) | right, nil
+}
+
+// halves returns the initial bit sizes of the left and right half. For odd
+// bit widths the left half is one bit wider, except under encoding version 1.
+func (fs *FeistelShuffler) halves() (int, int) {
+	if fs.evenSplit {
+		return fs.halfBits, fs.halfBits
+	}
+	return fs.bitWidth - fs.halfBits, fs.halfBits
+}
+
+// lossy reports whether Encode drops the top bit of odd-width inputs, which
+// only encoding version 1 does; values in the upper half then collide.
+func (fs *FeistelShuffler) lossy() bool {
+	return fs.evenSplit && fs.bitWidth%2 == 1 && fs.rounds > 0
+}
+
+// halfMask returns a mask covering the given number of low bits.
+func halfMask(bits int) uint64 {
+	return (uint64(1) << bits) - 1
 }
 
 // MaxValue returns the maximum value that can be shuffled.
@@ -159,13 +285,48 @@ func (fs *FeistelShuffler) Rounds() int {
 	return fs.rounds
 }
 
-// roundFunction implements the Feistel round function using FNV hash.
+// NewKeyedFeistelShuffler creates a shuffler whose round function is HMAC-SHA256
+// keyed with a secret of at least MinKeyBytes. Unlike the FNV round function,
+// observing input/output pairs does not reveal the permutation without the key.
+func NewKeyedFeistelShuffler(bitWidth, rounds int, key []byte) (*FeistelShuffler, error) {
+	return NewFeistelShufflerWithOptions(ShuffleConfig{
+		BitWidth:      bitWidth,
+		Rounds:        rounds,
+		RoundFunction: RoundFunctionHMACSHA256,
+		Key:           key,
+	})
+}
+
+// RoundFunction returns the round function in use.
+func (fs *FeistelShuffler) RoundFunction() RoundFunction {
+	return fs.options.RoundFunction
+}
+
+// Options returns a copy of the parameters the shuffler was built from,
+// with the round function resolved.
+func (fs *FeistelShuffler) Options() ShuffleConfig {
+	options := fs.options
+	options.Key = append([]byte(nil), options.Key...)
+	return options
+}
+
+// roundFunction implements the Feistel round function using FNV hash,
+// or HMAC-SHA256 when the shuffler is keyed.
 func (fs *FeistelShuffler) roundFunction(input, key uint64) uint64 {
+	if fs.secret != nil {
+		return fs.keyedRoundFunction(input, key)
+	}
+
 	h := fnv.New64a()
 	_ = binary.Write(h, binary.LittleEndian, input)
 	_ = binary.Write(h, binary.LittleEndian, key)
-	result := h.Sum64()
+	return h.Sum64()
+}
 
-	// Mask to half-bit width to ensure proper size
-	return result & fs.mask
+// keyedRoundFunction implements the Feistel round function using HMAC-SHA256.
+func (fs *FeistelShuffler) keyedRoundFunction(input, key uint64) uint64 {
+	mac := hmac.New(sha256.New, fs.secret)
+	_ = binary.Write(mac, binary.LittleEndian, key)
+	_ = binary.Write(mac, binary.LittleEndian, input)
+	return binary.LittleEndian.Uint64(mac.Sum(nil))
 }
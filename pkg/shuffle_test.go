@@ -276,3 +276,213 @@ func TestNoRoundsShuffling(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyedCrossPlatformConsistency(t *testing.T) {
+	shuffler, err := NewKeyedFeistelShuffler(64, 4, []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("NewKeyedFeistelShuffler() error = %v", err)
+	}
+	if shuffler.RoundFunction() != RoundFunctionHMACSHA256 {
+		t.Errorf("RoundFunction() = %q, want %q", shuffler.RoundFunction(), RoundFunctionHMACSHA256)
+	}
+
+	testCases := []struct {
+		input, encoded uint64
+	}{
+		{0, 13192541483869374104},
+		{42, 12723960396024825286},
+		{1337, 7245023887554142236},
+		{math.MaxUint64, 13081177071811069816},
+	}
+
+	for _, tc := range testCases {
+		actual, _ := shuffler.Encode(tc.input)
+		if actual != tc.encoded {
+			t.Errorf("Cross-platform inconsistency: input=%d, expected=%d, got=%d",
+				tc.input, tc.encoded, actual)
+		}
+
+		reversed, _ := shuffler.Decode(actual)
+		if reversed != tc.input {
+			t.Errorf("Bijection failed: input=%d, encoded=%d, decoded=%d",
+				tc.input, actual, reversed)
+		}
+	}
+}
+
+func TestKeyedFeistelShufflerCompleteBijection(t *testing.T) {
+	shuffler, _ := NewKeyedFeistelShuffler(12, 4, []byte("0123456789abcdef"))
+
+	seen := make(map[uint64]bool)
+	for i := range shuffler.MaxValue() + 1 {
+		encoded, _ := shuffler.Encode(i)
+		if seen[encoded] {
+			t.Fatalf("Encode(%d) = %d is a duplicate", i, encoded)
+		}
+		seen[encoded] = true
+
+		if decoded, _ := shuffler.Decode(encoded); decoded != i {
+			t.Fatalf("Decode(%d) = %d, want %d", encoded, decoded, i)
+		}
+	}
+}
+
+func TestShuffleConfigRoundFunction(t *testing.T) {
+	key := []byte("0123456789abcdef")
+
+	tests := []struct {
+		name    string
+		config  ShuffleConfig
+		want    RoundFunction
+		wantErr bool
+	}{
+		{"default is fnv", ShuffleConfig{BitWidth: 32, Rounds: 4}, RoundFunctionFNV, false},
+		{"hmac", ShuffleConfig{BitWidth: 32, Rounds: 4, RoundFunction: RoundFunctionHMACSHA256, Key: key}, RoundFunctionHMACSHA256, false},
+		{"hmac short key", ShuffleConfig{BitWidth: 32, Rounds: 4, RoundFunction: RoundFunctionHMACSHA256, Key: key[:15]}, "", true},
+		{"fnv with key", ShuffleConfig{BitWidth: 32, Rounds: 4, Key: key}, "", true},
+		{"unknown", ShuffleConfig{BitWidth: 32, Rounds: 4, RoundFunction: "md5"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shuffler, err := NewFeistelShufflerWithOptions(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFeistelShufflerWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && shuffler.RoundFunction() != tt.want {
+				t.Errorf("RoundFunction() = %q, want %q", shuffler.RoundFunction(), tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyedFeistelShufflerKeysDiffer(t *testing.T) {
+	a, _ := NewKeyedFeistelShuffler(32, 4, []byte("0123456789abcdef"))
+	b, _ := NewKeyedFeistelShuffler(32, 4, []byte("0123456789abcdeg"))
+
+	same := 0
+	for i := range uint64(100) {
+		x, _ := a.Encode(i)
+		y, _ := b.Encode(i)
+		if x == y {
+			same++
+		}
+	}
+	if same > 1 {
+		t.Errorf("%d of 100 values encode identically under different keys", same)
+	}
+}
+
+// Encoding version 1 must keep the odd-width outputs of earlier releases; IDs are published.
+func TestOddBitWidthConsistency(t *testing.T) {
+	testCases := []struct {
+		version int
+		input   uint64
+		encoded uint64
+	}{
+		// Unpinned shufflers keep the version 1 outputs
+		{0, 0, 1448},
+		{0, 4000, 1162},
+		{1, 0, 1448},
+		{1, 1, 1017},
+		{1, 4000, 1162},
+		{2, 0, 5544},
+		{2, 1, 5113},
+		{2, 4000, 5258},
+	}
+
+	for _, tc := range testCases {
+		shuffler, err := NewFeistelShufflerWithOptions(ShuffleConfig{
+			BitWidth: 13, Rounds: 4, Seed: 42, EncodingVersion: tc.version,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, _ := shuffler.Encode(tc.input)
+		if actual != tc.encoded {
+			t.Errorf("version %d: Encode(%d) = %d, want %d", tc.version, tc.input, actual, tc.encoded)
+		}
+
+		reversed, _ := shuffler.Decode(actual)
+		if reversed != tc.input {
+			t.Errorf("version %d: Decode(%d) = %d, want %d", tc.version, actual, reversed, tc.input)
+		}
+	}
+}
+
+func TestFeistelShufflerOddBitWidthBijection(t *testing.T) {
+	for _, bitWidth := range []int{5, 7, 13} {
+		for _, rounds := range []int{3, 4} {
+			shuffler, _ := NewFeistelShufflerWithOptions(ShuffleConfig{
+				BitWidth: bitWidth, Rounds: rounds, Seed: 42, EncodingVersion: EncodingVersion,
+			})
+
+			used := make(map[uint64]bool)
+			for i := range shuffler.MaxValue() + 1 {
+				encoded, _ := shuffler.Encode(i)
+				if encoded > shuffler.MaxValue() || used[encoded] {
+					t.Fatalf("bitWidth=%d rounds=%d: Encode(%d) = %d is out of range or a duplicate",
+						bitWidth, rounds, i, encoded)
+				}
+				used[encoded] = true
+
+				if decoded, _ := shuffler.Decode(encoded); decoded != i {
+					t.Fatalf("bitWidth=%d rounds=%d: Decode(%d) = %d, want %d",
+						bitWidth, rounds, encoded, decoded, i)
+				}
+			}
+		}
+	}
+}
+
+func TestRoundLimitsMatchConstruction(t *testing.T) {
+	key := []byte("0123456789abcdef")
+
+	for _, roundFunction := range []RoundFunction{RoundFunctionFNV, RoundFunctionHMACSHA256} {
+		minRounds, maxRounds := RoundLimits(roundFunction)
+		for rounds := minRounds - 1; rounds <= maxRounds+1; rounds++ {
+			options := ShuffleConfig{BitWidth: 16, Rounds: rounds, RoundFunction: roundFunction}
+			if roundFunction == RoundFunctionHMACSHA256 {
+				options.Key = key
+			}
+
+			validateErr := options.Validate()
+			_, constructErr := NewFeistelShufflerWithOptions(options)
+			if (validateErr == nil) != (constructErr == nil) {
+				t.Errorf("%s rounds=%d: Validate() = %v but construction = %v",
+					roundFunction, rounds, validateErr, constructErr)
+			}
+
+			wantValid := rounds >= minRounds && rounds <= maxRounds
+			if (validateErr == nil) != wantValid {
+				t.Errorf("%s rounds=%d: Validate() = %v, want valid=%v", roundFunction, rounds, validateErr, wantValid)
+			}
+		}
+	}
+}
+
+func TestFeistelShufflerOptions(t *testing.T) {
+	shuffler, err := NewFeistelShuffler(16, MaxRounds, 7)
+	if err != nil {
+		t.Fatalf("NewFeistelShuffler() error = %v", err)
+	}
+
+	options := shuffler.Options()
+	if options.BitWidth != 16 || options.Rounds != MaxRounds || options.Seed != 7 ||
+		options.RoundFunction != RoundFunctionFNV {
+		t.Errorf("Options() = %+v", options)
+	}
+
+	rebuilt, err := NewFeistelShufflerWithOptions(options)
+	if err != nil {
+		t.Fatalf("NewFeistelShufflerWithOptions() error = %v", err)
+	}
+	for _, input := range []uint64{0, 1337, 65535} {
+		a, _ := shuffler.Encode(input)
+		b, _ := rebuilt.Encode(input)
+		if a != b {
+			t.Errorf("rebuilt shuffler Encode(%d) = %d, want %d", input, b, a)
+		}
+	}
+}
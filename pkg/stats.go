@@ -0,0 +1,80 @@
+package phonid
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+type (
+	// PatternStats describes the capacity of a single pattern.
+	PatternStats struct {
+		Pattern      string
+		Length       int // Number of positions
+		Combinations PositiveInt
+		EntropyBits  float64     // log2(Combinations)
+		MinValue     PositiveInt // Smallest number Encode maps to this pattern
+		MaxValue     PositiveInt // Largest number Encode maps to this pattern
+		CharsPerBit  float64     // Average word length in runes / EntropyBits; lower is denser
+	}
+
+	// EncoderStats summarizes the capacity of a PhoneticEncoder.
+	EncoderStats struct {
+		Patterns          []PatternStats // ordered by Combinations ascending
+		TotalCombinations PositiveInt    // Number of distinct words across all patterns
+		MaxValue          PositiveInt    // Largest encodable number
+		EntropyBits       float64        // Entropy of the largest pattern
+		BitWidth          int            // Shuffle bit width derived from the largest pattern
+	}
+)
+
+// Stats reports per-pattern capacity, entropy and value ranges, plus totals.
+// Value ranges follow Encode: each number uses the smallest pattern that fits it.
+func (e *PhoneticEncoder) Stats() EncoderStats {
+	stats := EncoderStats{
+		Patterns: make([]PatternStats, 0, len(e.patternEncoders)),
+	}
+
+	minValue := PositiveInt(0)
+	for _, pattern := range e.patternEncoders {
+		entropy := math.Log2(float64(pattern.totalCombinations))
+
+		// Syllables make word length vary; use the average length per position
+		averageLength := 0.0
+		for _, position := range pattern.positions {
+			runes := 0
+			for _, symbol := range position.symbols {
+				runes += utf8.RuneCountInString(symbol)
+			}
+			averageLength += float64(runes) / float64(position.base)
+		}
+
+		charsPerBit := 0.0
+		if entropy > 0 {
+			charsPerBit = averageLength / entropy
+		}
+
+		stats.Patterns = append(stats.Patterns, PatternStats{
+			Pattern:      pattern.pattern,
+			Length:       pattern.length,
+			Combinations: pattern.totalCombinations,
+			EntropyBits:  entropy,
+			MinValue:     minValue,
+			MaxValue:     pattern.totalCombinations - 1,
+			CharsPerBit:  charsPerBit,
+		})
+
+		stats.TotalCombinations += pattern.totalCombinations
+		minValue = pattern.totalCombinations
+	}
+
+	if len(e.patternEncoders) == 0 {
+		return stats
+	}
+
+	largest := stats.Patterns[len(stats.Patterns)-1]
+	stats.MaxValue = largest.MaxValue
+	stats.EntropyBits = largest.EntropyBits
+	stats.BitWidth = calculateRequiredBitWidth(int(largest.Combinations))
+
+	return stats
+}
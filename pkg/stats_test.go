@@ -0,0 +1,73 @@
+package phonid_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestPhoneticEncoder_Stats(t *testing.T) {
+	encoder, err := NewPhoneticEncoder(&PhonidConfig{
+		Patterns: []string{"CVCVC", "CVC"},
+		Placeholders: PlaceholderMap{
+			Vowel:     RuneSet("aoi"),
+			Consonant: RuneSet("bzk"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	stats := encoder.Stats()
+
+	want := []PatternStats{
+		{Pattern: "CVC", Length: 3, Combinations: 27, MinValue: 0, MaxValue: 26},
+		{Pattern: "CVCVC", Length: 5, Combinations: 243, MinValue: 27, MaxValue: 242},
+	}
+	if len(stats.Patterns) != len(want) {
+		t.Fatalf("got %d pattern stats, want %d", len(stats.Patterns), len(want))
+	}
+
+	for i, w := range want {
+		got := stats.Patterns[i]
+		if got.Pattern != w.Pattern || got.Length != w.Length || got.Combinations != w.Combinations ||
+			got.MinValue != w.MinValue || got.MaxValue != w.MaxValue {
+			t.Errorf("Patterns[%d] = %+v, want %+v", i, got, w)
+		}
+
+		wantEntropy := math.Log2(float64(w.Combinations))
+		if math.Abs(got.EntropyBits-wantEntropy) > 1e-9 {
+			t.Errorf("Patterns[%d].EntropyBits = %f, want %f", i, got.EntropyBits, wantEntropy)
+		}
+		if math.Abs(got.CharsPerBit-float64(w.Length)/wantEntropy) > 1e-9 {
+			t.Errorf("Patterns[%d].CharsPerBit = %f, want %f", i, got.CharsPerBit, float64(w.Length)/wantEntropy)
+		}
+	}
+
+	if stats.TotalCombinations != 270 {
+		t.Errorf("TotalCombinations = %d, want 270", stats.TotalCombinations)
+	}
+	if stats.MaxValue != 242 {
+		t.Errorf("MaxValue = %d, want 242", stats.MaxValue)
+	}
+	if stats.BitWidth != 8 {
+		t.Errorf("BitWidth = %d, want 8", stats.BitWidth)
+	}
+}
+
+func TestPhoneticEncoder_StatsMatchesConfigBitWidth(t *testing.T) {
+	cfg, err := NewConfigWithOptions(WithPhonetic(&ProQuintConfig))
+	if err != nil {
+		t.Fatalf("NewConfigWithOptions() error = %v", err)
+	}
+
+	encoder, err := NewPhoneticEncoder(cfg.Phonetic)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	if got := encoder.Stats().BitWidth; got != cfg.Shuffle.BitWidth {
+		t.Errorf("Stats().BitWidth = %d, want %d", got, cfg.Shuffle.BitWidth)
+	}
+}
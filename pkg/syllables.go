@@ -0,0 +1,175 @@
+package phonid
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// parseState tracks two patterns consuming the same word in lockstep.
+	// pending is the part of the leading pattern's last symbol that the
+	// trailing pattern has not matched yet; leftAhead tells which one leads.
+	parseState struct {
+		left, right int
+		pending     string
+		leftAhead   bool
+	}
+)
+
+// validatePrefixFree ensures no member of a set is empty or a prefix of another,
+// which makes the symbol at each position unambiguous.
+func validatePrefixFree(placeholder PlaceholderType, members []string) error {
+	for i, a := range members {
+		if a == "" {
+			return fmt.Errorf("placeholder '%c' contains an empty syllable", placeholder)
+		}
+		for j, b := range members {
+			if i != j && strings.HasPrefix(b, a) {
+				return fmt.Errorf("placeholder '%c': syllable %q is a prefix of %q", placeholder, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// symbolRuneRange returns the rune lengths of the shortest and longest member.
+func symbolRuneRange(members []string) (int, int) {
+	shortest, longest := 0, 0
+	for i, member := range members {
+		n := utf8.RuneCountInString(member)
+		if i == 0 || n < shortest {
+			shortest = n
+		}
+		if n > longest {
+			longest = n
+		}
+	}
+	return shortest, longest
+}
+
+// validateUniqueParsing ensures that no word can be produced by two patterns.
+// With single-rune placeholders this holds by distinct pattern lengths; with
+// syllables, words of different patterns may have equal length.
+func validateUniqueParsing(patternEncoders []*PatternEncoder) error {
+	for i := range patternEncoders {
+		for j := i + 1; j < len(patternEncoders); j++ {
+			a, b := patternEncoders[i], patternEncoders[j]
+			if a.maxRunes < b.minRunes || b.maxRunes < a.minRunes {
+				continue
+			}
+			if word, ok := sharedWord(a, b); ok {
+				return fmt.Errorf("patterns '%s' and '%s' can both produce %q", a.pattern, b.pattern, word)
+			}
+		}
+	}
+	return nil
+}
+
+// sharedWord searches for a word parseable by both patterns, walking them in
+// lockstep. The state space is finite since pending is always a symbol suffix.
+func sharedWord(a, b *PatternEncoder) (string, bool) {
+	visited := make(map[parseState]bool)
+
+	var walk func(state parseState, word string) (string, bool)
+	walk = func(state parseState, word string) (string, bool) {
+		if visited[state] {
+			return "", false
+		}
+		visited[state] = true
+
+		if state.pending == "" {
+			if state.left == a.length && state.right == b.length {
+				return word, true
+			}
+			if state.left == a.length || state.right == b.length {
+				return "", false
+			}
+
+			// Both patterns start a symbol at the same offset
+			for _, left := range a.positions[state.left].symbols {
+				for _, right := range b.positions[state.right].symbols {
+					next, longer, ok := startBoth(state, left, right)
+					if !ok {
+						continue
+					}
+					if found, ok := walk(next, word+longer); ok {
+						return found, true
+					}
+				}
+			}
+			return "", false
+		}
+
+		// Only the trailing pattern advances until it catches up
+		trailing, index := a, state.left
+		if state.leftAhead {
+			trailing, index = b, state.right
+		}
+		if index == trailing.length {
+			return "", false
+		}
+
+		for _, symbol := range trailing.positions[index].symbols {
+			next, ok := catchUp(state, symbol)
+			if !ok {
+				continue
+			}
+			extended := word
+			if len(symbol) > len(state.pending) {
+				extended += symbol[len(state.pending):]
+			}
+			if found, ok := walk(next, extended); ok {
+				return found, true
+			}
+		}
+		return "", false
+	}
+
+	return walk(parseState{}, "")
+}
+
+// startBoth advances both patterns by one symbol from a synchronized state and
+// returns the longer of the two symbols.
+func startBoth(state parseState, left, right string) (parseState, string, bool) {
+	next := parseState{left: state.left + 1, right: state.right + 1}
+	switch {
+	case left == right:
+		return next, left, true
+	case strings.HasPrefix(right, left):
+		next.pending = right[len(left):]
+		return next, right, true
+	case strings.HasPrefix(left, right):
+		next.pending = left[len(right):]
+		next.leftAhead = true
+		return next, left, true
+	default:
+		return parseState{}, "", false
+	}
+}
+
+// catchUp lets the trailing pattern consume symbol against the pending text.
+func catchUp(state parseState, symbol string) (parseState, bool) {
+	next := state
+	if state.leftAhead {
+		next.right++
+	} else {
+		next.left++
+	}
+
+	switch {
+	case symbol == state.pending:
+		next.pending = ""
+		next.leftAhead = false
+	case strings.HasPrefix(state.pending, symbol):
+		next.pending = state.pending[len(symbol):]
+	case strings.HasPrefix(symbol, state.pending):
+		// The trailing pattern overtakes the leading one
+		next.pending = symbol[len(state.pending):]
+		next.leftAhead = !state.leftAhead
+	default:
+		return parseState{}, false
+	}
+
+	return next, true
+}
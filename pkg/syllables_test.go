@@ -0,0 +1,161 @@
+package phonid_test
+
+import (
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func syllableConfig() *PhonidConfig {
+	return &PhonidConfig{
+		Patterns: []string{"CVC", "CVCVC"},
+		Placeholders: PlaceholderMap{
+			Vowel: RuneSet("aeio"),
+		},
+		Syllables: SyllableMap{
+			Consonant: {"sh", "th", "ng", "k", "m"},
+		},
+	}
+}
+
+func TestSyllables_EncodeDecode(t *testing.T) {
+	encoder, err := NewPhoneticEncoder(syllableConfig())
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"shash", 0},
+		{"mem", 89},
+		{"shakoth", 76},
+		{"ngithong", 1037},
+	}
+
+	for _, tt := range tests {
+		got, err := encoder.Decode(tt.word)
+		if err != nil {
+			t.Fatalf("Decode(%q) error = %v", tt.word, err)
+		}
+		if got != tt.want {
+			t.Errorf("Decode(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+
+	for _, word := range []string{"sha", "shakothx", "sxa", "nen", ""} {
+		if _, err := encoder.Decode(word); err == nil {
+			t.Errorf("Decode(%q) should fail", word)
+		}
+	}
+}
+
+func TestSyllables_CompleteBijection(t *testing.T) {
+	encoder, err := NewPhoneticEncoder(syllableConfig())
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+
+	stats := encoder.Stats()
+	seen := make(map[string]bool)
+	for n := range stats.MaxValue + 1 {
+		word, err := encoder.Encode(n)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", n, err)
+		}
+		if seen[word] {
+			t.Fatalf("Encode(%d) = %q is a duplicate", n, word)
+		}
+		seen[word] = true
+
+		decoded, err := encoder.Decode(word)
+		if err != nil {
+			t.Fatalf("Decode(%q) error = %v", word, err)
+		}
+		if decoded != int(n) {
+			t.Fatalf("Decode(%q) = %d, want %d", word, decoded, n)
+		}
+	}
+}
+
+func TestSyllables_Validate(t *testing.T) {
+	vowels := PlaceholderMap{Vowel: RuneSet("aeio")}
+
+	tests := []struct {
+		name         string
+		placeholders PlaceholderMap
+		syllables    SyllableMap
+	}{
+		{"not prefix-free", vowels, SyllableMap{Consonant: {"s", "sh", "k"}}},
+		{"empty syllable", vowels, SyllableMap{Consonant: {"", "sh", "k"}}},
+		{"duplicate syllable", vowels, SyllableMap{Consonant: {"sh", "sh", "k"}}},
+		{"defined twice", vowels, SyllableMap{Consonant: {"sh", "th", "k"}, Vowel: {"a", "e"}}},
+		{"too few complement members", vowels, SyllableMap{Consonant: {"sh", "th"}}},
+		{
+			"vowel syllable with consonant",
+			PlaceholderMap{Consonant: RuneSet("bdk")},
+			SyllableMap{Vowel: {"ar", "e"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &PhonidConfig{
+				Patterns:     []string{"CVC"},
+				Placeholders: tt.placeholders,
+				Syllables:    tt.syllables,
+			}
+			if err := config.Validate(); err == nil {
+				t.Error("Validate() should fail")
+			}
+		})
+	}
+}
+
+func TestSyllables_AmbiguousPatterns(t *testing.T) {
+	config := &PhonidConfig{
+		Patterns: []string{"CCV", "VCVCV"},
+		Syllables: SyllableMap{
+			Consonant: {"ak", "k", "ta"},
+			Vowel:     {"a", "ea"},
+		},
+	}
+
+	// "ak"+"ak"+"a" and "a"+"k"+"a"+"k"+"a" both spell "akaka"
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if _, err := NewPhoneticEncoder(config); err == nil {
+		t.Error("NewPhoneticEncoder() should reject patterns sharing a word")
+	}
+}
+
+func TestParsePhonidRCSyllables(t *testing.T) {
+	content := `
+[phonetic]
+patterns = ["CVC", "CVCVC"]
+
+[phonetic.placeholders]
+V = "aeio"
+
+[phonetic.syllables]
+C = ["sh", "th", "ng", "k", "m"]
+
+[[preflight]]
+input = 476
+output = "thakoth"
+`
+	config, preflight, err := ParsePhonidRC(content)
+	if err != nil {
+		t.Fatalf("ParsePhonidRC() error = %v", err)
+	}
+
+	encoder, err := NewPhoneticEncoder(config)
+	if err != nil {
+		t.Fatalf("NewPhoneticEncoder() error = %v", err)
+	}
+	if err := encoder.ValidatePreflight(preflight); err != nil {
+		t.Errorf("ValidatePreflight() error = %v", err)
+	}
+}
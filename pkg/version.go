@@ -0,0 +1,37 @@
+package phonid
+
+import "fmt"
+
+const (
+	// EncodingVersion identifies the encoding algorithm: the order in which
+	// patterns claim numbers, the mixed-radix direction (most significant
+	// position first) and the Feistel network with its FNV round function.
+	// It increments whenever one of them changes, and the previous behavior
+	// stays reachable by pinning the old version with WithEncodingVersion or
+	// encoding_version in the rc file.
+	//
+	// Version 2 splits odd bit widths into halves of different sizes.
+	// Version 1 masked both halves to the narrower size, which drops the top
+	// bit, so it only permutes the lower half of an odd-width number space.
+	EncodingVersion = 2
+
+	// DefaultEncodingVersion is used when no version is pinned. It stays at 1,
+	// so upgrading the library never changes published IDs; newer versions
+	// are opt-in.
+	DefaultEncodingVersion = 1
+
+	// MinEncodingVersion is the oldest encoding version this library reproduces.
+	MinEncodingVersion = 1
+)
+
+// versionUnevenSplit is the first version that splits odd bit widths unevenly.
+const versionUnevenSplit = 2
+
+// validateEncodingVersion rejects versions this library cannot reproduce.
+func validateEncodingVersion(version int) error {
+	if version < MinEncodingVersion || version > EncodingVersion {
+		return fmt.Errorf("encoding version %d is not supported (supported: %d-%d)",
+			version, MinEncodingVersion, EncodingVersion)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package phonid_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/iilei/phonid/pkg"
+)
+
+func TestConfig_EncodingVersion(t *testing.T) {
+	config, err := NewConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if config.EncodingVersion != DefaultEncodingVersion {
+		t.Errorf("EncodingVersion = %d, want it resolved to %d", config.EncodingVersion, DefaultEncodingVersion)
+	}
+
+	pinned, err := NewConfigWithOptions(WithEncodingVersion(DefaultEncodingVersion))
+	if err != nil {
+		t.Fatalf("pinning the default version: %v", err)
+	}
+	if got, want := mustFingerprint(t, pinned), mustFingerprint(t, config); got != want {
+		t.Errorf("pinned fingerprint = %s, want %s", got, want)
+	}
+
+	// 0 selects the default version, so -1 is the first invalid value below
+	for _, version := range []int{-1, EncodingVersion + 1} {
+		if _, err := NewConfigWithOptions(WithEncodingVersion(version)); err == nil {
+			t.Errorf("WithEncodingVersion(%d) expected error", version)
+		}
+	}
+}
+
+func TestParseConfigRC_EncodingVersion(t *testing.T) {
+	const checks = `
+[[preflight]]
+input = 0
+output = "babab"
+`
+	config, _, err := ParseConfigRC("encoding_version = 1\n" + checks)
+	if err != nil {
+		t.Fatalf("ParseConfigRC() error = %v", err)
+	}
+	if config.EncodingVersion != 1 {
+		t.Errorf("EncodingVersion = %d, want 1", config.EncodingVersion)
+	}
+
+	_, _, err = ParseConfigRC("encoding_version = 99\n" + checks)
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("ParseConfigRC() error = %v, want unsupported version", err)
+	}
+
+	// Written files always pin the version they were produced with
+	data, err := MarshalPhonidRC(config, []PreflightCheck{{Input: 0, Output: "babab"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\nencoding_version = 1\n") {
+		t.Errorf("MarshalPhonidRC() output does not pin the encoding version:\n%s", data)
+	}
+}
+
+func TestCodec_PinnedEncodingVersion(t *testing.T) {
+	// 125 words need an odd bit width of 7, where versions 1 and 2 differ
+	newCodec := func(version int) *Codec {
+		t.Helper()
+		config, err := NewConfigWithOptions(
+			WithPhonetic(&PhonidConfig{
+				Patterns:     []string{"CVC"},
+				Placeholders: PlaceholderMap{Consonant: RuneSet("bdkst"), Vowel: RuneSet("aeiou")},
+			}),
+			WithRounds(4), WithSeed(42), WithEncodingVersion(version),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		codec, err := NewCodec(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return codec
+	}
+
+	// Words issued before version 2, by the shuffler and encoder of that release
+	legacy := newCodec(1)
+	for number, want := range map[PositiveInt]string{0: "dus", 1: "kek", 42: "dib", 63: "dob"} {
+		word, err := legacy.Encode(number)
+		if err != nil || word != want {
+			t.Errorf("version 1: Encode(%d) = %q, %v, want %q", number, word, err, want)
+		}
+		if decoded, err := legacy.Decode(want); err != nil || decoded != int(number) {
+			t.Errorf("version 1: Decode(%q) = %d, %v, want %d", want, decoded, err, number)
+		}
+	}
+
+	// Configs that pin nothing keep the version 1 words
+	if word, err := newCodec(0).Encode(42); err != nil || word != "dib" {
+		t.Errorf("unpinned: Encode(42) = %q, %v, want %q", word, err, "dib")
+	}
+
+	// Version 1 only permutes the lower half of an odd-width space
+	if _, err := legacy.Encode(100); err == nil || !strings.Contains(err.Error(), "reversibly") {
+		t.Errorf("version 1: Encode(100) error = %v, want it rejected", err)
+	}
+
+	current := newCodec(EncodingVersion)
+	for number := range PositiveInt(current.MaxValue() + 1) {
+		word, err := current.Encode(number)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", number, err)
+		}
+		if decoded, err := current.Decode(word); err != nil || decoded != int(number) {
+			t.Fatalf("Decode(%q) = %d, %v, want %d", word, decoded, err, number)
+		}
+	}
+}